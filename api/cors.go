@@ -0,0 +1,16 @@
+package api
+
+import "net/http"
+
+// CORS wraps handler so browser clients on another origin can call the API.
+// RegisterRoutes already answers every route's OPTIONS preflight with 204;
+// CORS only needs to stamp the headers browsers check on every response,
+// preflight or not.
+func CORS(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		handler.ServeHTTP(w, r)
+	})
+}
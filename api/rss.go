@@ -2,18 +2,29 @@ package api
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"personal-kb/services/rss/config"
 	"personal-kb/services/rss/feed"
+	"personal-kb/services/rss/feed/render"
 	"personal-kb/services/rss/logging"
 )
 
-func HandleGetRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+// HandleGetRSS serves aggregated posts from the background-refreshed store
+// instead of fetching upstream feeds live, so a single slow or unreachable
+// feed can't block the request.
+func HandleGetRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string, store *feed.Store, contentCache *feed.ContentCache) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -34,9 +45,17 @@ func HandleGetRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string
 			return
 		}
 
-		timeout := time.Duration(cfg.FetchTimeoutSec) * time.Second
-		posts, feedErrs := feed.FetchAllFeeds(r.Context(), feeds, cfg.MaxPostsPerFeed, timeout)
+		var posts []feed.Post
+		if categories := q["category"]; len(categories) > 0 {
+			posts = postsByCategories(store, categories)
+		} else {
+			posts = store.ByDate()
+		}
+		if tags := splitAndTrim(q.Get("tags")); len(tags) > 0 {
+			posts = postsByTags(posts, tags)
+		}
 
+		feedErrs := store.Errors()
 		for _, fe := range feedErrs {
 			loggers.Error.Error("feed fetch failed", "feed", fe.FeedName, "url", fe.URL, "error", fe.Err.Error())
 		}
@@ -57,12 +76,297 @@ func HandleGetRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string
 			filtered = append(filtered, p)
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		if filtered == nil {
-			filtered = []feed.Post{} // ensure JSON [] not null
+		if query := q.Get("q"); query != "" {
+			filtered = filterBySubstring(filtered, query)
+		}
+
+		format := negotiateFormat(q.Get("format"), r.Header.Get("Accept"))
+
+		// Every format, including the default paginated JSON response,
+		// supports conditional GETs against an ETag/Last-Modified derived
+		// from the filtered entry set, ahead of any pagination.
+		etag := render.ETag(cfg, filtered, from)
+		lastModified := render.LastModified(filtered)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		if notModifiedSince(r, etag, lastModified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		enrich := func(posts []feed.Post) {
+			if cfg.EnableFulltext && q.Get("fulltext") == "1" {
+				timeout := time.Duration(cfg.FetchTimeoutSec) * time.Second
+				feed.EnrichWithFullText(r.Context(), posts, contentCache, timeout)
+			}
+		}
+
+		switch format {
+		case formatAtom:
+			enrich(filtered)
+			w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+			if err := render.RenderAtom(w, cfg, filtered, from, to); err != nil {
+				loggers.Error.Error("failed to render Atom feed", "error", err.Error())
+			}
+		case formatRSS:
+			enrich(filtered)
+			w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+			if err := render.RenderRSS(w, cfg, filtered, from, to); err != nil {
+				loggers.Error.Error("failed to render RSS feed", "error", err.Error())
+			}
+		case formatJSONFeed:
+			enrich(filtered)
+			w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+			if err := render.RenderJSONFeed(w, cfg, filtered, from, to); err != nil {
+				loggers.Error.Error("failed to render JSON Feed", "error", err.Error())
+			}
+		case formatLegacy:
+			// Preserved for clients that haven't migrated to the paginated
+			// {posts, next_cursor, has_more} envelope: the full filtered set,
+			// unpaginated, as a bare JSON array.
+			enrich(filtered)
+			w.Header().Set("Content-Type", "application/json")
+			if filtered == nil {
+				filtered = []feed.Post{}
+			}
+			json.NewEncoder(w).Encode(filtered)
+		default:
+			page, hasMore, err := paginateRSS(filtered, q.Get("cursor"), q.Get("limit"))
+			if err != nil {
+				http.Error(w, "invalid cursor", http.StatusBadRequest)
+				return
+			}
+			enrich(page)
+			var nextCursor string
+			if hasMore {
+				nextCursor = encodeCursor(page[len(page)-1])
+			}
+			if page == nil {
+				page = []feed.Post{}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(rssPage{
+				Posts:      page,
+				NextCursor: nextCursor,
+				HasMore:    hasMore,
+			})
+		}
+	}
+}
+
+const (
+	formatJSON     = "json"
+	formatAtom     = "atom"
+	formatRSS      = "rss"
+	formatJSONFeed = "jsonfeed"
+	formatLegacy   = "legacy"
+)
+
+// filterBySubstring keeps only posts whose title or summary contains query,
+// case-insensitively, for the ?q= search param.
+func filterBySubstring(posts []feed.Post, query string) []feed.Post {
+	query = strings.ToLower(query)
+	var out []feed.Post
+	for _, p := range posts {
+		if strings.Contains(strings.ToLower(p.Title), query) || strings.Contains(strings.ToLower(p.Summary), query) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// rssPage is the default GET /rss response shape: a page of posts plus a
+// cursor for fetching the next one, keyset-paginated on PublishedAt DESC
+// (with GUID as a tiebreaker) rather than offset-based, so pages stay
+// correct as new posts arrive between requests.
+type rssPage struct {
+	Posts      []feed.Post `json:"posts"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// rssCursor is the opaque, base64-encoded keyset cursor used by ?cursor= to
+// resume GET /rss pagination after a given post.
+type rssCursor struct {
+	PublishedAt time.Time `json:"published_at"`
+	GUID        string    `json:"guid"`
+}
+
+func encodeCursor(p feed.Post) string {
+	data, _ := json.Marshal(rssCursor{PublishedAt: p.PublishedAt, GUID: p.GUID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (rssCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return rssCursor{}, err
+	}
+	var c rssCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return rssCursor{}, err
+	}
+	return c, nil
+}
+
+// paginateRSS applies keyset pagination to posts, which must already be
+// sorted newest-first with GUID as a tiebreaker (feed.Store's own ordering).
+// It returns the page starting just after rawCursor (or from the start, if
+// empty), capped at limit (defaulting to defaultPageLimit, capped at
+// maxPageLimit), and whether further posts remain beyond the page.
+func paginateRSS(posts []feed.Post, rawCursor, rawLimit string) (page []feed.Post, hasMore bool, err error) {
+	limit := defaultPageLimit
+	if rawLimit != "" {
+		if n, convErr := strconv.Atoi(rawLimit); convErr == nil && n > 0 {
+			limit = n
 		}
-		json.NewEncoder(w).Encode(filtered)
 	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	start := 0
+	if rawCursor != "" {
+		cursor, decodeErr := decodeCursor(rawCursor)
+		if decodeErr != nil {
+			return nil, false, decodeErr
+		}
+		start = len(posts)
+		for i, p := range posts {
+			if p.PublishedAt.Equal(cursor.PublishedAt) && p.GUID == cursor.GUID {
+				start = i + 1
+				break
+			}
+			if p.PublishedAt.Before(cursor.PublishedAt) || (p.PublishedAt.Equal(cursor.PublishedAt) && p.GUID < cursor.GUID) {
+				start = i
+				break
+			}
+		}
+	}
+
+	remaining := posts[start:]
+	if len(remaining) > limit {
+		return remaining[:limit], true, nil
+	}
+	return remaining, false, nil
+}
+
+// negotiateFormat picks the response format for GET /rss. An explicit
+// ?format= query param wins; otherwise it's inferred from the Accept
+// header, defaulting to JSON for anything else (including "*/*").
+func negotiateFormat(formatParam, accept string) string {
+	switch formatParam {
+	case formatAtom, formatRSS, formatJSON, formatJSONFeed, formatLegacy:
+		return formatParam
+	}
+	if strings.Contains(accept, "application/atom+xml") {
+		return formatAtom
+	}
+	if strings.Contains(accept, "application/rss+xml") {
+		return formatRSS
+	}
+	if strings.Contains(accept, "application/feed+json") {
+		return formatJSONFeed
+	}
+	return formatJSON
+}
+
+// notModifiedSince reports whether r's conditional-GET headers (If-None-Match
+// taking precedence over If-Modified-Since, per RFC 7232) indicate the
+// client's cached copy is still current.
+func notModifiedSince(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return inm == etag
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !lastModified.After(t)
+		}
+	}
+	return false
+}
+
+// HandleFeedXSL serves the bundled stylesheet referenced by the
+// <?xml-stylesheet?> processing instruction in rendered Atom/RSS output.
+func HandleFeedXSL() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xsl; charset=utf-8")
+		io.WriteString(w, render.XSL)
+	}
+}
+
+// postsByCategories returns the union of cached posts for every given
+// category, newest first with GUID as a tiebreaker (feed.Store's own
+// ordering, which paginateRSS relies on), deduplicated by feed+URL.
+func postsByCategories(store *feed.Store, categories []string) []feed.Post {
+	seen := make(map[string]bool)
+	var posts []feed.Post
+	for _, c := range categories {
+		for _, p := range store.ByCategory(c) {
+			key := p.FeedName + "|" + p.URL
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			posts = append(posts, p)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if !posts[i].PublishedAt.Equal(posts[j].PublishedAt) {
+			return posts[i].PublishedAt.After(posts[j].PublishedAt)
+		}
+		return posts[i].GUID > posts[j].GUID
+	})
+	return posts
+}
+
+// postsByTags filters posts down to those annotated with at least one of
+// tags.
+func postsByTags(posts []feed.Post, tags []string) []feed.Post {
+	var filtered []feed.Post
+	for _, p := range posts {
+		for _, want := range tags {
+			if hasString(p.Tags, want) {
+				filtered = append(filtered, p)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func hasString(haystack []string, want string) bool {
+	for _, s := range haystack {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAndTrim splits a comma-separated query value into its non-empty,
+// whitespace-trimmed parts, e.g. "a, b,,c" -> ["a","b","c"].
+func splitAndTrim(csv string) []string {
+	if csv == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(csv, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
 }
 
 func HandlePutRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
@@ -73,8 +377,12 @@ func HandlePutRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string
 		}
 
 		var req struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
+			Name          string   `json:"name"`
+			URL           string   `json:"url"`
+			Categories    []string `json:"categories"`
+			Tags          []string `json:"tags"`
+			TitleTemplate string   `json:"title_template"`
+			URLTemplate   string   `json:"url_template"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid JSON body", http.StatusBadRequest)
@@ -85,15 +393,20 @@ func HandlePutRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string
 			return
 		}
 
+		newFeed := feed.Feed{Name: req.Name, URL: req.URL, Categories: req.Categories, Tags: req.Tags, TitleTemplate: req.TitleTemplate, URLTemplate: req.URLTemplate}
+		if _, err := feed.CompileTemplates(newFeed); err != nil {
+			http.Error(w, fmt.Sprintf("invalid title_template/url_template: %v", err), http.StatusBadRequest)
+			return
+		}
+
 		// Validate the feed URL
 		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(cfg.FetchTimeoutSec)*time.Second)
 		defer cancel()
-		if err := feed.ValidateFeed(ctx, req.URL); err != nil {
+		if err := feed.ValidateFeed(ctx, req.Name, req.URL); err != nil {
 			http.Error(w, fmt.Sprintf("URL is not a valid RSS/Atom feed: %v", err), http.StatusUnprocessableEntity)
 			return
 		}
 
-		newFeed := feed.Feed{Name: req.Name, URL: req.URL}
 		if err := feed.AddFeed(feedsPath, newFeed); err != nil {
 			if errors.Is(err, feed.ErrDuplicateFeed) {
 				loggers.Warn.Warn("duplicate feed URL rejected", "url", req.URL)
@@ -116,6 +429,389 @@ func HandlePutRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string
 	}
 }
 
+// HandleDeleteRSS removes the feed identified by its percent-encoded URL
+// (the {url} path wildcard on DELETE /rss/{url}), returning 404 if no feed
+// has that URL.
+func HandleDeleteRSS(loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		feedURL, err := url.PathUnescape(r.PathValue("url"))
+		if err != nil || feedURL == "" {
+			http.Error(w, "invalid feed URL in path", http.StatusBadRequest)
+			return
+		}
+
+		if err := feed.RemoveFeed(feedsPath, feedURL); err != nil {
+			if errors.Is(err, feed.ErrFeedNotFound) {
+				http.Error(w, "feed not found", http.StatusNotFound)
+				return
+			}
+			loggers.Error.Error("failed to remove feed", "url", feedURL, "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		loggers.Info.Info("feed removed", "url", feedURL)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"message": "feed removed"})
+	}
+}
+
+// HandlePatchRSS renames a feed and/or changes its URL, identified by its
+// current percent-encoded URL (the {url} path wildcard on PATCH /rss/{url}).
+// A new URL is re-validated via feed.ValidateFeed before being saved.
+func HandlePatchRSS(cfg *config.Config, loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		feedURL, err := url.PathUnescape(r.PathValue("url"))
+		if err != nil || feedURL == "" {
+			http.Error(w, "invalid feed URL in path", http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" && req.URL == "" {
+			http.Error(w, "name and/or url is required", http.StatusBadRequest)
+			return
+		}
+
+		if req.URL != "" {
+			ctx, cancel := context.WithTimeout(r.Context(), time.Duration(cfg.FetchTimeoutSec)*time.Second)
+			defer cancel()
+			name := req.Name
+			if name == "" {
+				name = feedURL
+			}
+			if err := feed.ValidateFeed(ctx, name, req.URL); err != nil {
+				http.Error(w, fmt.Sprintf("URL is not a valid RSS/Atom feed: %v", err), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+
+		updated, err := feed.UpdateFeed(feedsPath, feedURL, req.Name, req.URL)
+		if err != nil {
+			if errors.Is(err, feed.ErrFeedNotFound) {
+				http.Error(w, "feed not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, feed.ErrDuplicateFeed) {
+				loggers.Warn.Warn("duplicate feed URL rejected", "url", req.URL)
+				http.Error(w, "feed URL already exists", http.StatusConflict)
+				return
+			}
+			loggers.Error.Error("failed to update feed", "url", feedURL, "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		loggers.Info.Info("feed updated", "url", feedURL, "new_name", updated.Name, "new_url", updated.URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(updated)
+	}
+}
+
+// feedStatus is the GET /rss/feeds and GET /feeds/status response shape: a
+// configured feed annotated with its last known fetch and scheduling state.
+type feedStatus struct {
+	Name                string    `json:"name"`
+	URL                 string    `json:"url"`
+	ETag                string    `json:"etag,omitempty"`
+	LastFetched         time.Time `json:"last_fetched,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures,omitempty"`
+	NextFetch           time.Time `json:"next_fetch,omitempty"`
+}
+
+// cacheStats is the raw feed-body cache hit/miss counters reported alongside
+// feed statuses, or all zeros if the scheduler has no CacheDir configured.
+type cacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// feedsResponse is the GET /rss/feeds response shape.
+type feedsResponse struct {
+	Feeds []feedStatus `json:"feeds"`
+	Cache cacheStats   `json:"cache"`
+}
+
+func toFeedStatuses(fs []feed.FeedStatus) []feedStatus {
+	out := make([]feedStatus, 0, len(fs))
+	for _, s := range fs {
+		out = append(out, feedStatus{
+			Name:                s.Name,
+			URL:                 s.URL,
+			ETag:                s.ETag,
+			LastFetched:         s.LastFetched,
+			LastError:           s.LastError,
+			ConsecutiveFailures: s.ConsecutiveFailures,
+			NextFetch:           s.NextFetch,
+		})
+	}
+	return out
+}
+
+// HandleGetFeeds lists configured feeds along with their cached conditional-GET
+// state and the scheduler's raw-body cache hit/miss counters, so clients can
+// see when each feed was last fetched without triggering a new fetch.
+func HandleGetFeeds(loggers *logging.Loggers, scheduler *feed.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		statuses, err := scheduler.Status()
+		if err != nil {
+			loggers.Error.Error("failed to read feeds file", "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		hits, misses := scheduler.CacheStats()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(feedsResponse{
+			Feeds: toFeedStatuses(statuses),
+			Cache: cacheStats{Hits: hits, Misses: misses},
+		})
+	}
+}
+
+// HandleGetFeedsStatus reports each configured feed's last-fetch time,
+// last error, consecutive-failure count, and next scheduled fetch — the
+// scheduler's own view of what's due and what's backing off, as opposed to
+// HandleGetFeeds's client-facing envelope.
+func HandleGetFeedsStatus(loggers *logging.Loggers, scheduler *feed.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		statuses, err := scheduler.Status()
+		if err != nil {
+			loggers.Error.Error("failed to read feeds file", "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(toFeedStatuses(statuses))
+	}
+}
+
+// HandleRefreshRSS forces an immediate refresh, bypassing each feed's
+// configured interval, and blocks until it completes. With no ?url= query
+// param every configured feed is refreshed; with one, only that feed is.
+func HandleRefreshRSS(loggers *logging.Loggers, scheduler *feed.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if feedURL := r.URL.Query().Get("url"); feedURL != "" {
+			if err := scheduler.RefreshFeed(r.Context(), feedURL); err != nil {
+				if errors.Is(err, feed.ErrFeedNotConfigured) {
+					http.Error(w, "feed not found", http.StatusNotFound)
+					return
+				}
+				loggers.Error.Error("failed to refresh feed", "url", feedURL, "error", err.Error())
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			loggers.Info.Info("forced feed refresh completed", "url", feedURL)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"message": "refresh complete", "url": feedURL})
+			return
+		}
+
+		scheduler.RefreshNow(r.Context())
+		loggers.Info.Info("forced feed refresh completed")
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"message": "refresh complete"})
+	}
+}
+
+// HandlePostFeedCategories reassigns the categories for the feed whose URL is
+// embedded (percent-encoded) in the request path, e.g.
+// POST /rss/https%3A%2F%2Fexample.com%2Frss/categories.
+func HandlePostFeedCategories(loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		feedURL, err := url.PathUnescape(r.PathValue("url"))
+		if err != nil || feedURL == "" {
+			http.Error(w, "invalid feed URL in path", http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Categories []string `json:"categories"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+
+		updated, err := feed.SetFeedCategories(feedsPath, feedURL, req.Categories)
+		if err != nil {
+			if errors.Is(err, feed.ErrFeedNotFound) {
+				http.Error(w, "feed not found", http.StatusNotFound)
+				return
+			}
+			loggers.Error.Error("failed to set feed categories", "url", feedURL, "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		loggers.Info.Info("feed categories updated", "url", feedURL, "categories", strings.Join(updated.Categories, ","))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(updated)
+	}
+}
+
+// HandleGetCategories lists every distinct category assigned to any
+// configured feed.
+func HandleGetCategories(loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		categories, err := feed.ListCategories(feedsPath)
+		if err != nil {
+			loggers.Error.Error("failed to list categories", "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"categories": categories})
+	}
+}
+
+// HandlePutCategories renames a category across every feed that carries it.
+func HandlePutCategories(loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req struct {
+			Old string `json:"old"`
+			New string `json:"new"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		if req.Old == "" || req.New == "" {
+			http.Error(w, "old and new are required", http.StatusBadRequest)
+			return
+		}
+
+		if err := feed.RenameCategory(feedsPath, req.Old, req.New); err != nil {
+			if errors.Is(err, feed.ErrCategoryNotFound) {
+				http.Error(w, "category not found", http.StatusNotFound)
+				return
+			}
+			loggers.Error.Error("failed to rename category", "old", req.Old, "new", req.New, "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		loggers.Info.Info("category renamed", "old", req.Old, "new", req.New)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"message": "category renamed"})
+	}
+}
+
+// opmlImportResult reports the outcome of importing a single OPML outline
+// entry.
+type opmlImportResult struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Status string `json:"status"` // "added", "duplicate", or "invalid"
+	Error  string `json:"error,omitempty"`
+}
+
+// HandlePostOPML imports an OPML 2.0 document of feeds, validating and
+// adding each one, skipping duplicates, and reporting a per-entry status.
+func HandlePostOPML(cfg *config.Config, loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		entries, err := feed.ParseOPML(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid OPML: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		results := make([]opmlImportResult, 0, len(entries))
+		for _, f := range entries {
+			ctx, cancel := context.WithTimeout(r.Context(), time.Duration(cfg.FetchTimeoutSec)*time.Second)
+			validateErr := feed.ValidateFeed(ctx, f.Name, f.URL)
+			cancel()
+			if validateErr != nil {
+				results = append(results, opmlImportResult{Name: f.Name, URL: f.URL, Status: "invalid", Error: validateErr.Error()})
+				continue
+			}
+
+			if err := feed.AddFeed(feedsPath, f); err != nil {
+				if errors.Is(err, feed.ErrDuplicateFeed) {
+					results = append(results, opmlImportResult{Name: f.Name, URL: f.URL, Status: "duplicate"})
+					continue
+				}
+				loggers.Error.Error("failed to add feed from OPML import", "url", f.URL, "error", err.Error())
+				results = append(results, opmlImportResult{Name: f.Name, URL: f.URL, Status: "error", Error: err.Error()})
+				continue
+			}
+			results = append(results, opmlImportResult{Name: f.Name, URL: f.URL, Status: "added"})
+		}
+
+		loggers.Info.Info("OPML import completed", "entries", len(results))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	}
+}
+
+// HandleGetOPML exports the configured feeds as an OPML 2.0 document.
+func HandleGetOPML(loggers *logging.Loggers, feedsPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		feeds, err := feed.LoadFeeds(feedsPath)
+		if err != nil {
+			loggers.Error.Error("failed to read feeds file", "path", feedsPath, "error", err.Error())
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/x-opml+xml")
+		if err := feed.WriteOPML(w, feeds); err != nil {
+			loggers.Error.Error("failed to write OPML export", "error", err.Error())
+		}
+	}
+}
+
 func parseDateRange(rangeParam, fromParam, toParam string, defaultRange string) (from, to time.Time, err error) {
 	// Explicit from/to take precedence
 	if fromParam != "" || toParam != "" {
@@ -161,19 +857,59 @@ func parseDateRange(rangeParam, fromParam, toParam string, defaultRange string)
 	}
 }
 
-func RegisterRoutes(mux *http.ServeMux, cfg *config.Config, loggers *logging.Loggers) {
+// RegisterRoutes wires the RSS endpoints into mux. scheduler supplies the
+// background-refreshed Store and StateStore that the handlers read from.
+func RegisterRoutes(mux *http.ServeMux, cfg *config.Config, loggers *logging.Loggers, scheduler *feed.Scheduler) {
 	feedsPath := cfg.DataFile
+	store := scheduler.Store()
 
-	mux.HandleFunc("/rss", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			HandleGetRSS(cfg, loggers, feedsPath)(w, r)
-		case http.MethodPut:
-			HandlePutRSS(cfg, loggers, feedsPath)(w, r)
-		case http.MethodOptions:
-			w.WriteHeader(http.StatusNoContent)
-		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	// Reuse the scheduler's ContentCache, shared with its own fetch-time
+	// full-content scraping, so the two don't maintain separate on-disk
+	// caches for the same articles. If the scheduler wasn't given a
+	// ContentCachePath, fall back to opening one here so on-demand
+	// ?fulltext=1 requests still get caching.
+	contentCache := scheduler.ContentCache()
+	if contentCache == nil {
+		cc, err := feed.NewContentCache(filepath.Join(cfg.DataDir, "fulltext-cache.yaml"))
+		if err != nil {
+			loggers.Error.Error("failed to load full-text cache, full-text extraction will be uncached", "error", err.Error())
 		}
-	})
+		contentCache = cc
+	}
+
+	preflight := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+
+	mux.HandleFunc("GET /rss", HandleGetRSS(cfg, loggers, feedsPath, store, contentCache))
+	mux.HandleFunc("PUT /rss", HandlePutRSS(cfg, loggers, feedsPath))
+	mux.HandleFunc("OPTIONS /rss", preflight)
+
+	// DELETE/PATCH /rss/{url} — url is percent-encoded to allow embedded
+	// slashes, matching /rss/{url}/categories below.
+	mux.HandleFunc("DELETE /rss/{url}", HandleDeleteRSS(loggers, feedsPath))
+	mux.HandleFunc("PATCH /rss/{url}", HandlePatchRSS(cfg, loggers, feedsPath))
+	mux.HandleFunc("OPTIONS /rss/{url}", preflight)
+
+	mux.HandleFunc("POST /rss/{url}/categories", HandlePostFeedCategories(loggers, feedsPath))
+	mux.HandleFunc("OPTIONS /rss/{url}/categories", preflight)
+
+	mux.HandleFunc("GET /rss/feeds", HandleGetFeeds(loggers, scheduler))
+	mux.HandleFunc("OPTIONS /rss/feeds", preflight)
+
+	mux.HandleFunc("GET /feeds/status", HandleGetFeedsStatus(loggers, scheduler))
+	mux.HandleFunc("OPTIONS /feeds/status", preflight)
+
+	mux.HandleFunc("GET /categories", HandleGetCategories(loggers, feedsPath))
+	mux.HandleFunc("PUT /categories", HandlePutCategories(loggers, feedsPath))
+	mux.HandleFunc("OPTIONS /categories", preflight)
+
+	mux.HandleFunc("GET /rss/refresh", HandleRefreshRSS(loggers, scheduler))
+	mux.HandleFunc("POST /rss/refresh", HandleRefreshRSS(loggers, scheduler))
+	mux.HandleFunc("OPTIONS /rss/refresh", preflight)
+
+	mux.HandleFunc("GET /feed.xsl", HandleFeedXSL())
+	mux.HandleFunc("OPTIONS /feed.xsl", preflight)
+
+	mux.HandleFunc("GET /rss/opml", HandleGetOPML(loggers, feedsPath))
+	mux.HandleFunc("POST /rss/opml", HandlePostOPML(cfg, loggers, feedsPath))
+	mux.HandleFunc("OPTIONS /rss/opml", preflight)
 }
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"personal-kb/services/rss/feed"
+)
+
+func postAt(guid string, seconds int64) feed.Post {
+	return feed.Post{GUID: guid, Title: guid, PublishedAt: time.Unix(seconds, 0)}
+}
+
+func TestFilterBySubstring_MatchesTitleOrSummaryCaseInsensitive(t *testing.T) {
+	posts := []feed.Post{
+		{Title: "Go 1.22 released", Summary: "new features"},
+		{Title: "Weekly digest", Summary: "covers GOLANG news"},
+		{Title: "Unrelated", Summary: "nothing here"},
+	}
+
+	got := filterBySubstring(posts, "go")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(got))
+	}
+}
+
+func TestPaginateRSS_FirstPageRespectsLimit(t *testing.T) {
+	posts := []feed.Post{postAt("c", 300), postAt("b", 200), postAt("a", 100)}
+
+	page, hasMore, err := paginateRSS(posts, "", "2")
+	if err != nil {
+		t.Fatalf("paginateRSS: %v", err)
+	}
+	if !hasMore {
+		t.Errorf("expected hasMore=true")
+	}
+	if len(page) != 2 || page[0].GUID != "c" || page[1].GUID != "b" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
+
+func TestPaginateRSS_CursorResumesAfterLastSeen(t *testing.T) {
+	posts := []feed.Post{postAt("c", 300), postAt("b", 200), postAt("a", 100)}
+
+	first, hasMore, err := paginateRSS(posts, "", "2")
+	if err != nil {
+		t.Fatalf("paginateRSS first page: %v", err)
+	}
+	if !hasMore {
+		t.Fatalf("expected a second page")
+	}
+
+	cursor := encodeCursor(first[len(first)-1])
+	second, hasMore, err := paginateRSS(posts, cursor, "2")
+	if err != nil {
+		t.Fatalf("paginateRSS second page: %v", err)
+	}
+	if hasMore {
+		t.Errorf("expected no further pages")
+	}
+	if len(second) != 1 || second[0].GUID != "a" {
+		t.Errorf("unexpected second page: %+v", second)
+	}
+}
+
+func TestPaginateRSS_DefaultAndCappedLimit(t *testing.T) {
+	posts := make([]feed.Post, 0, 600)
+	for i := 0; i < 600; i++ {
+		posts = append(posts, postAt(string(rune('a'+i%26))+time.Unix(int64(600-i), 0).String(), int64(600-i)))
+	}
+
+	page, _, err := paginateRSS(posts, "", "")
+	if err != nil {
+		t.Fatalf("paginateRSS: %v", err)
+	}
+	if len(page) != defaultPageLimit {
+		t.Errorf("expected default limit %d, got %d", defaultPageLimit, len(page))
+	}
+
+	page, _, err = paginateRSS(posts, "", "10000")
+	if err != nil {
+		t.Fatalf("paginateRSS: %v", err)
+	}
+	if len(page) != maxPageLimit {
+		t.Errorf("expected capped limit %d, got %d", maxPageLimit, len(page))
+	}
+}
+
+func TestPaginateRSS_InvalidCursorErrors(t *testing.T) {
+	posts := []feed.Post{postAt("a", 100)}
+
+	if _, _, err := paginateRSS(posts, "not-valid-base64!!", "10"); err == nil {
+		t.Errorf("expected an error for a malformed cursor")
+	}
+}
@@ -10,12 +10,28 @@ import (
 )
 
 type Config struct {
-	Host            string `mapstructure:"host"`
+	Host string `mapstructure:"host"`
+	// Port is the TCP port to listen on. When the process is socket-activated
+	// (systemd/launchd, handled by internal/listenfd) the inherited socket is
+	// used and Port is ignored, so zero is the usual value there. Without
+	// socket activation, zero is passed through to net.Listen, which then
+	// picks an OS-assigned ephemeral port.
 	Port            int    `mapstructure:"port"`
 	MaxPostsPerFeed int    `mapstructure:"max_posts_per_feed"`
 	FetchTimeoutSec int    `mapstructure:"fetch_timeout_sec"`
+	DataDir         string `mapstructure:"data_dir"`
 	DataFile        string `mapstructure:"data_file"`
-	DefaultRange    string `mapstructure:"default_range"`
+	// CacheDir holds the on-disk raw feed body cache used for conditional
+	// GETs. Empty disables raw-body caching (parsed posts are still cached
+	// via DataDir's state.yaml regardless).
+	CacheDir     string `mapstructure:"cache_dir"`
+	DefaultRange string `mapstructure:"default_range"`
+	// RefreshIntervalSec is how often a feed is re-fetched by the background
+	// scheduler when the feed itself doesn't set its own interval.
+	RefreshIntervalSec int `mapstructure:"refresh_interval_sec"`
+	// EnableFulltext allows GET /rss?fulltext=1 to fetch and distill each
+	// post's linked article when the feed itself didn't include a body.
+	EnableFulltext bool `mapstructure:"enable_fulltext"`
 }
 
 func setDefaults(v *viper.Viper) {
@@ -23,8 +39,12 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("port", 9001)
 	v.SetDefault("max_posts_per_feed", 50)
 	v.SetDefault("fetch_timeout_sec", 10)
+	v.SetDefault("data_dir", "./data")
 	v.SetDefault("data_file", "./data/feeds.yaml")
+	v.SetDefault("cache_dir", "./data/cache")
 	v.SetDefault("default_range", "last_month")
+	v.SetDefault("refresh_interval_sec", 300)
+	v.SetDefault("enable_fulltext", false)
 }
 
 func Load(configPath string) (*Config, error) {
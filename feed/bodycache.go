@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// BodyCache persists each feed's most recently fetched raw response body to
+// disk, keyed by a hash of its URL, and tracks conditional-GET hit/miss
+// counts. Entries older than maxAge, or the oldest entries once the
+// directory exceeds maxSizeBytes, are pruned on every Put.
+type BodyCache struct {
+	dir          string
+	maxAge       time.Duration
+	maxSizeBytes int64
+
+	hits   int64
+	misses int64
+}
+
+// NewBodyCache returns a BodyCache rooted at dir, creating it if needed.
+func NewBodyCache(dir string, maxAge time.Duration, maxSizeBytes int64) (*BodyCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &BodyCache{dir: dir, maxAge: maxAge, maxSizeBytes: maxSizeBytes}, nil
+}
+
+func (c *BodyCache) path(feedURL string) string {
+	sum := sha256.Sum256([]byte(feedURL))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".body")
+}
+
+// Get returns the cached raw body for feedURL, if any.
+func (c *BodyCache) Get(feedURL string) ([]byte, bool) {
+	body, err := os.ReadFile(c.path(feedURL))
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// Put writes body as the cached copy for feedURL and prunes the cache
+// directory of stale or excess entries.
+func (c *BodyCache) Put(feedURL string, body []byte) error {
+	if err := os.WriteFile(c.path(feedURL), body, 0644); err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+// RecordHit and RecordMiss track whether a conditional fetch reused cached
+// content (304, or skipped the network entirely) or pulled fresh content.
+func (c *BodyCache) RecordHit()  { atomic.AddInt64(&c.hits, 1) }
+func (c *BodyCache) RecordMiss() { atomic.AddInt64(&c.misses, 1) }
+
+// Hits and Misses report the cumulative cache hit/miss counts since the
+// BodyCache was created.
+func (c *BodyCache) Hits() int64   { return atomic.LoadInt64(&c.hits) }
+func (c *BodyCache) Misses() int64 { return atomic.LoadInt64(&c.misses) }
+
+// evict removes entries older than maxAge, then — if the directory still
+// exceeds maxSizeBytes — removes the oldest remaining entries until it
+// doesn't.
+func (c *BodyCache) evict() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type cachedFile struct {
+		path    string
+		modTime time.Time
+		size    int64
+	}
+	var files []cachedFile
+	var total int64
+	now := time.Now()
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(c.dir, e.Name())
+		if c.maxAge > 0 && now.Sub(info.ModTime()) > c.maxAge {
+			os.Remove(path)
+			continue
+		}
+		files = append(files, cachedFile{path: path, modTime: info.ModTime(), size: info.Size()})
+		total += info.Size()
+	}
+
+	if c.maxSizeBytes <= 0 || total <= c.maxSizeBytes {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= c.maxSizeBytes {
+			break
+		}
+		if err := os.Remove(f.path); err != nil {
+			continue
+		}
+		total -= f.size
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBodyCache_PutGetRoundTrips(t *testing.T) {
+	cache, err := NewBodyCache(t.TempDir(), time.Hour, 1<<20)
+	if err != nil {
+		t.Fatalf("NewBodyCache: %v", err)
+	}
+
+	if _, ok := cache.Get("https://example.com/feed.xml"); ok {
+		t.Fatalf("expected miss before Put")
+	}
+
+	if err := cache.Put("https://example.com/feed.xml", []byte("<rss/>")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	body, ok := cache.Get("https://example.com/feed.xml")
+	if !ok {
+		t.Fatalf("expected hit after Put")
+	}
+	if string(body) != "<rss/>" {
+		t.Errorf("got body %q, want %q", body, "<rss/>")
+	}
+}
+
+func TestBodyCache_HitMissCounters(t *testing.T) {
+	cache, err := NewBodyCache(t.TempDir(), time.Hour, 1<<20)
+	if err != nil {
+		t.Fatalf("NewBodyCache: %v", err)
+	}
+
+	cache.RecordMiss()
+	cache.RecordHit()
+	cache.RecordHit()
+
+	if got := cache.Hits(); got != 2 {
+		t.Errorf("Hits() = %d, want 2", got)
+	}
+	if got := cache.Misses(); got != 1 {
+		t.Errorf("Misses() = %d, want 1", got)
+	}
+}
+
+func TestBodyCache_EvictsEntriesOlderThanMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewBodyCache(dir, time.Hour, 1<<20)
+	if err != nil {
+		t.Fatalf("NewBodyCache: %v", err)
+	}
+
+	if err := cache.Put("https://example.com/stale.xml", []byte("old")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	stalePath := cache.path("https://example.com/stale.xml")
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(stalePath, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if err := cache.Put("https://example.com/fresh.xml", []byte("new")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if _, ok := cache.Get("https://example.com/stale.xml"); ok {
+		t.Errorf("expected stale entry to be evicted by age")
+	}
+	if _, ok := cache.Get("https://example.com/fresh.xml"); !ok {
+		t.Errorf("expected fresh entry to survive eviction")
+	}
+}
+
+func TestBodyCache_EvictsOldestWhenOverSize(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewBodyCache(dir, 0, 10)
+	if err != nil {
+		t.Fatalf("NewBodyCache: %v", err)
+	}
+
+	if err := cache.Put("https://example.com/a.xml", []byte("aaaaa")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	oldPath := cache.path("https://example.com/a.xml")
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(oldPath, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if err := cache.Put("https://example.com/b.xml", []byte("bbbbb")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := cache.Put("https://example.com/c.xml", []byte("ccccc")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if _, ok := cache.Get("https://example.com/a.xml"); ok {
+		t.Errorf("expected oldest entry to be evicted once over size budget")
+	}
+	if _, ok := cache.Get("https://example.com/c.xml"); !ok {
+		t.Errorf("expected newest entry to survive eviction")
+	}
+}
+
+func TestNewBodyCache_CreatesDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "cache")
+	if _, err := NewBodyCache(dir, time.Hour, 1<<20); err != nil {
+		t.Fatalf("NewBodyCache: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("expected cache dir to be created: %v", err)
+	}
+}
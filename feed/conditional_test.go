@@ -0,0 +1,140 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFetchFeedConditional_ReturnsNotModifiedOn304(t *testing.T) {
+	cached := []Post{{FeedName: "F", Title: "Cached Post", URL: "https://example.com/cached"}}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		t.Errorf("expected conditional request with If-None-Match, got none")
+	}))
+	defer srv.Close()
+
+	prev := FeedState{ETag: `"v1"`, Posts: cached}
+	posts, state, notModified, err := FetchFeedConditional(context.Background(), srv.URL, 10, prev, FetchOptions{}, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !notModified {
+		t.Error("expected notModified to be true on 304")
+	}
+	if len(posts) != 1 || posts[0].Title != "Cached Post" {
+		t.Errorf("expected cached posts to be reused, got %+v", posts)
+	}
+	if state.ETag != `"v1"` {
+		t.Errorf("expected ETag to be preserved, got %q", state.ETag)
+	}
+	if requests != 1 {
+		t.Errorf("expected exactly 1 request, got %d", requests)
+	}
+}
+
+func TestFetchFeedConditional_SkipsNetworkWhileFresh(t *testing.T) {
+	cached := []Post{{FeedName: "F", Title: "Cached Post", URL: "https://example.com/cached"}}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, sampleRSS)
+	}))
+	defer srv.Close()
+
+	prev := FeedState{Posts: cached, FreshUntil: time.Now().Add(time.Hour)}
+	posts, _, notModified, err := FetchFeedConditional(context.Background(), srv.URL, 10, prev, FetchOptions{}, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !notModified {
+		t.Error("expected notModified to be true when still fresh")
+	}
+	if len(posts) != 1 || posts[0].Title != "Cached Post" {
+		t.Errorf("expected cached posts to be reused, got %+v", posts)
+	}
+	if requests != 0 {
+		t.Errorf("expected freshness window to skip the network entirely, got %d requests", requests)
+	}
+}
+
+func TestFetchFeedConditional_ForceRefreshIgnoresFreshness(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, sampleRSS)
+	}))
+	defer srv.Close()
+
+	prev := FeedState{FreshUntil: time.Now().Add(time.Hour)}
+	_, _, _, err := FetchFeedConditional(context.Background(), srv.URL, 10, prev, FetchOptions{ForceRefresh: true}, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected ForceRefresh to still hit the network, got %d requests", requests)
+	}
+}
+
+func TestFetchFeedConditional_FallsBackToBodyCacheOnNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sampleRSS)
+	}))
+	feedURL := srv.URL
+
+	cache, err := NewBodyCache(filepath.Join(t.TempDir(), "bodies"), time.Hour, 0)
+	if err != nil {
+		t.Fatalf("NewBodyCache: %v", err)
+	}
+	if _, _, _, err := FetchFeedConditional(context.Background(), feedURL, 10, FeedState{}, FetchOptions{}, nil, cache); err != nil {
+		t.Fatalf("priming fetch: %v", err)
+	}
+	srv.Close()
+
+	posts, _, notModified, err := FetchFeedConditional(context.Background(), feedURL, 10, FeedState{}, FetchOptions{ForceRefresh: true}, nil, cache)
+	if err != nil {
+		t.Fatalf("expected BodyCache fallback to avoid an error, got: %v", err)
+	}
+	if notModified {
+		t.Error("expected notModified to be false when served from the BodyCache fallback")
+	}
+	if len(posts) == 0 {
+		t.Error("expected posts parsed from the cached raw body")
+	}
+}
+
+func TestParseFreshness_CacheControlMaxAge(t *testing.T) {
+	header := http.Header{"Cache-Control": []string{"public, max-age=120"}}
+	fetchedAt := time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC)
+
+	got := parseFreshness(header, fetchedAt)
+	want := fetchedAt.Add(120 * time.Second)
+	if !got.Equal(want) {
+		t.Errorf("parseFreshness = %v, want %v", got, want)
+	}
+}
+
+func TestParseFreshness_Expires(t *testing.T) {
+	header := http.Header{"Expires": []string{"Mon, 16 Feb 2026 12:00:00 GMT"}}
+
+	got := parseFreshness(header, time.Now())
+	want := time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("parseFreshness = %v, want %v", got, want)
+	}
+}
+
+func TestParseFreshness_NoHeaders(t *testing.T) {
+	if got := parseFreshness(http.Header{}, time.Now()); !got.IsZero() {
+		t.Errorf("expected zero time with no caching headers, got %v", got)
+	}
+}
@@ -1,21 +1,38 @@
 package feed
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
-	"sort"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed"
 )
 
 type Post struct {
-	FeedName    string    `json:"feed_name"`
-	Title       string    `json:"title"`
-	URL         string    `json:"url"`
+	FeedName string `json:"feed_name"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	// GUID uniquely identifies this post within its feed, taken from the
+	// feed's own guid/id element when present and falling back to URL
+	// otherwise. Used as the tiebreaker in keyset pagination cursors, since
+	// PublishedAt alone isn't guaranteed unique.
+	GUID        string    `json:"guid,omitempty"`
 	PublishedAt time.Time `json:"published_at"`
+	Summary     string    `json:"summary,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	// Categories and Tags are copied from the source feed's own Categories
+	// and Tags at fetch time, so clients can group/filter posts without a
+	// second lookup against the feeds list.
+	Categories []string `json:"categories,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
 }
 
 type FeedError struct {
@@ -28,17 +45,194 @@ func (e FeedError) Error() string {
 	return fmt.Sprintf("feed %q (%s): %v", e.FeedName, e.URL, e.Err)
 }
 
-func FetchFeed(ctx context.Context, feedURL string, maxPosts int) ([]Post, error) {
+// FetchFeed fetches and parses feedURL via gofeed, which detects and handles
+// RSS 1.0/2.0, Atom 1.0, and JSON Feed 1.1 from the response body regardless
+// of the Content-Type header. feedName, when non-empty, overrides the
+// feed's own declared title on every returned Post. tmpl, if non-nil, is
+// applied to each post before it's returned.
+func FetchFeed(ctx context.Context, feedName, feedURL string, maxPosts int, tmpl *Templates) ([]Post, error) {
 	parser := gofeed.NewParser()
 	parser.Client = &http.Client{
 		Transport: http.DefaultTransport,
 	}
+	parser.UserAgent = userAgent
 
 	f, err := parser.ParseURLWithContext(feedURL, ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	posts := postsFromFeed(f, maxPosts, tmpl)
+	if feedName != "" {
+		for i := range posts {
+			posts[i].FeedName = feedName
+		}
+	}
+	return posts, nil
+}
+
+// ValidateFeed reports whether url can be fetched and parsed as a feed.
+func ValidateFeed(ctx context.Context, feedName, url string) error {
+	_, err := FetchFeed(ctx, feedName, url, 1, nil)
+	return err
+}
+
+// FetchOptions controls how FetchFeedConditional treats previously cached
+// state.
+type FetchOptions struct {
+	// ForceRefresh skips the Cache-Control/Expires freshness check and
+	// always hits the network, still sending conditional-GET validators.
+	ForceRefresh bool
+}
+
+// FetchFeedConditional fetches feedURL honoring any ETag/Last-Modified
+// validators recorded in prev via If-None-Match/If-Modified-Since. If prev
+// is still fresh per its recorded Cache-Control/Expires validity and opts
+// doesn't force a refresh, the network is skipped entirely. If the server
+// answers 304, or the response body hashes to the same ContentHash as prev,
+// notModified is true and the caller should keep using prev.Posts rather
+// than re-parsing. Otherwise it returns the freshly parsed posts along with
+// the new state to persist. tmpl, if non-nil, is applied to each freshly
+// parsed post before it's returned. cache, if non-nil, records a miss (plus
+// the new raw body) whenever a fresh body is parsed, and a hit whenever
+// cached content is reused instead; if the network request itself fails,
+// cache is also consulted for a last-known-good raw body rather than
+// failing the fetch outright, which counts as a hit even though
+// notModified is then false.
+func FetchFeedConditional(ctx context.Context, feedURL string, maxPosts int, prev FeedState, opts FetchOptions, tmpl *Templates, cache *BodyCache) (posts []Post, state FeedState, notModified bool, err error) {
+	if !opts.ForceRefresh && !prev.FreshUntil.IsZero() && time.Now().Before(prev.FreshUntil) {
+		if cache != nil {
+			cache.RecordHit()
+		}
+		return prev.Posts, prev, true, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, FeedState{}, false, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if cache != nil {
+			if body, ok := cache.Get(feedURL); ok {
+				cache.RecordHit()
+				return parseCachedBody(body, prev, maxPosts, tmpl)
+			}
+		}
+		return nil, FeedState{}, false, err
+	}
+	defer resp.Body.Close()
+
+	now := time.Now().UTC()
+
+	if resp.StatusCode == http.StatusNotModified {
+		state = prev
+		state.LastFetched = now
+		state.LastError = ""
+		state.FreshUntil = parseFreshness(resp.Header, now)
+		if cache != nil {
+			cache.RecordHit()
+		}
+		return prev.Posts, state, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, FeedState{}, false, fmt.Errorf("fetch %s: unexpected status %d", feedURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, FeedState{}, false, err
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+
+	state = FeedState{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentHash:  hash,
+		LastFetched:  now,
+		FreshUntil:   parseFreshness(resp.Header, now),
+	}
+
+	if hash == prev.ContentHash && prev.ContentHash != "" {
+		state.Posts = prev.Posts
+		if cache != nil {
+			cache.RecordHit()
+		}
+		return prev.Posts, state, true, nil
+	}
+
+	if cache != nil {
+		cache.RecordMiss()
+		cache.Put(feedURL, body)
+	}
+
+	f, err := gofeed.NewParser().Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, FeedState{}, false, err
+	}
+
+	posts = postsFromFeed(f, maxPosts, tmpl)
+	state.Posts = posts
+	return posts, state, false, nil
+}
+
+// parseCachedBody re-parses a BodyCache-saved raw body as a stand-in for a
+// network fetch that failed outright, preserving prev's validators (since
+// there's no fresh response to read them from) so the next successful fetch
+// still sends a proper conditional GET.
+func parseCachedBody(body []byte, prev FeedState, maxPosts int, tmpl *Templates) ([]Post, FeedState, bool, error) {
+	f, err := gofeed.NewParser().Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, FeedState{}, false, err
+	}
+
+	sum := sha256.Sum256(body)
+	state := prev
+	state.ContentHash = hex.EncodeToString(sum[:])
+	state.LastFetched = time.Now().UTC()
+	state.LastError = ""
+
+	posts := postsFromFeed(f, maxPosts, tmpl)
+	state.Posts = posts
+	return posts, state, false, nil
+}
+
+// parseFreshness computes the time before which a response can be
+// considered fresh, from Cache-Control's max-age directive if present,
+// falling back to the Expires header. It returns the zero Time if neither
+// header is present or parseable, meaning "no freshness window".
+func parseFreshness(header http.Header, fetchedAt time.Time) time.Time {
+	if cc := header.Get("Cache-Control"); cc != "" {
+		for _, part := range strings.Split(cc, ",") {
+			part = strings.TrimSpace(part)
+			rest, ok := strings.CutPrefix(part, "max-age=")
+			if !ok {
+				continue
+			}
+			if secs, err := strconv.Atoi(rest); err == nil {
+				return fetchedAt.Add(time.Duration(secs) * time.Second)
+			}
+		}
+	}
+	if exp := header.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func postsFromFeed(f *gofeed.Feed, maxPosts int, tmpl *Templates) []Post {
 	var posts []Post
 	for i, item := range f.Items {
 		if i >= maxPosts {
@@ -51,56 +245,37 @@ func FetchFeed(ctx context.Context, feedURL string, maxPosts int) ([]Post, error
 			published = item.UpdatedParsed.UTC()
 		}
 
-		posts = append(posts, Post{
+		guid := item.GUID
+		if guid == "" {
+			guid = item.Link
+		}
+
+		post := Post{
 			FeedName:    f.Title,
 			Title:       item.Title,
 			URL:         item.Link,
+			GUID:        guid,
 			PublishedAt: published,
-		})
+			Summary:     item.Description,
+			Content:     item.Content,
+			Author:      itemAuthor(item),
+		}
+		if tmpl != nil {
+			post = tmpl.Apply(item, post)
+		}
+		posts = append(posts, post)
 	}
-	return posts, nil
+	return posts
 }
 
-func ValidateFeed(ctx context.Context, url string) error {
-	_, err := FetchFeed(ctx, url, 1)
-	return err
-}
-
-func FetchAllFeeds(ctx context.Context, feeds []Feed, maxPostsPerFeed int, timeout time.Duration) ([]Post, []FeedError) {
-	var (
-		mu       sync.Mutex
-		allPosts []Post
-		errs     []FeedError
-		wg       sync.WaitGroup
-	)
-
-	for _, f := range feeds {
-		wg.Add(1)
-		go func(fd Feed) {
-			defer wg.Done()
-			fetchCtx, cancel := context.WithTimeout(ctx, timeout)
-			defer cancel()
-
-			posts, err := FetchFeed(fetchCtx, fd.URL, maxPostsPerFeed)
-			mu.Lock()
-			defer mu.Unlock()
-			if err != nil {
-				errs = append(errs, FeedError{FeedName: fd.Name, URL: fd.URL, Err: err})
-				return
-			}
-			// Override feed name with the user-configured name
-			for i := range posts {
-				posts[i].FeedName = fd.Name
-			}
-			allPosts = append(allPosts, posts...)
-		}(f)
+// itemAuthor prefers the single Author field gofeed populates for RSS/Atom,
+// falling back to the first entry of Authors (used for JSON Feed items).
+func itemAuthor(item *gofeed.Item) string {
+	if item.Author != nil && item.Author.Name != "" {
+		return item.Author.Name
 	}
-
-	wg.Wait()
-
-	sort.Slice(allPosts, func(i, j int) bool {
-		return allPosts[i].PublishedAt.After(allPosts[j].PublishedAt)
-	})
-
-	return allPosts, errs
+	if len(item.Authors) > 0 && item.Authors[0].Name != "" {
+		return item.Authors[0].Name
+	}
+	return ""
 }
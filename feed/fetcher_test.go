@@ -39,7 +39,7 @@ func TestFetchFeed_ValidRSS(t *testing.T) {
 	defer srv.Close()
 
 	testFeedName := "Hot Feed"
-	posts, err := FetchFeed(context.Background(), testFeedName, srv.URL, 2)
+	posts, err := FetchFeed(context.Background(), testFeedName, srv.URL, 2, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -60,6 +60,91 @@ func TestFetchFeed_ValidRSS(t *testing.T) {
 	}
 }
 
+const sampleAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom Feed</title>
+  <entry>
+    <title>Atom Post One</title>
+    <link href="https://example.com/atom/1"/>
+    <updated>2026-02-16T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+const sampleJSONFeed = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "JSON Feed",
+  "items": [
+    {
+      "id": "1",
+      "title": "JSON Post One",
+      "url": "https://example.com/json/1",
+      "date_published": "2026-02-16T10:00:00Z"
+    }
+  ]
+}`
+
+func TestFetchFeed_Atom(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		fmt.Fprint(w, sampleAtom)
+	}))
+	defer srv.Close()
+
+	posts, err := FetchFeed(context.Background(), "", srv.URL, 10, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(posts))
+	}
+	if posts[0].Title != "Atom Post One" || posts[0].URL != "https://example.com/atom/1" {
+		t.Errorf("unexpected post: %+v", posts[0])
+	}
+	if posts[0].FeedName != "Atom Feed" {
+		t.Errorf("posts[0].FeedName = %q, want %q", posts[0].FeedName, "Atom Feed")
+	}
+	if posts[0].PublishedAt.IsZero() {
+		t.Error("posts[0].PublishedAt should not be zero")
+	}
+}
+
+func TestFetchFeed_JSONFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		fmt.Fprint(w, sampleJSONFeed)
+	}))
+	defer srv.Close()
+
+	posts, err := FetchFeed(context.Background(), "", srv.URL, 10, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(posts))
+	}
+	if posts[0].Title != "JSON Post One" || posts[0].URL != "https://example.com/json/1" {
+		t.Errorf("unexpected post: %+v", posts[0])
+	}
+	if posts[0].FeedName != "JSON Feed" {
+		t.Errorf("posts[0].FeedName = %q, want %q", posts[0].FeedName, "JSON Feed")
+	}
+}
+
+func TestFetchFeed_NoContentTypeStillSniffs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sampleAtom)
+	}))
+	defer srv.Close()
+
+	posts, err := FetchFeed(context.Background(), "", srv.URL, 10, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posts) != 1 || posts[0].Title != "Atom Post One" {
+		t.Fatalf("expected Atom feed to parse without a Content-Type header, got %+v", posts)
+	}
+}
+
 func TestFetchFeed_InvalidContent(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
@@ -67,7 +152,7 @@ func TestFetchFeed_InvalidContent(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := FetchFeed(context.Background(), "", srv.URL, 10)
+	_, err := FetchFeed(context.Background(), "", srv.URL, 10, nil)
 	if err == nil {
 		t.Error("expected error for non-feed content, got nil")
 	}
@@ -83,7 +168,7 @@ func TestFetchFeed_Timeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 	defer cancel()
 
-	_, err := FetchFeed(ctx, "", srv.URL, 10)
+	_, err := FetchFeed(ctx, "", srv.URL, 10, nil)
 	if err == nil {
 		t.Error("expected timeout error, got nil")
 	}
@@ -113,72 +198,6 @@ func TestValidateFeed_NotAFeed(t *testing.T) {
 	}
 }
 
-func TestFetchAllFeeds_AllSucceed(t *testing.T) {
-	srv1 := newFeedServer("Feed A", "2026-02-16T10:00:00Z")
-	defer srv1.Close()
-	srv2 := newFeedServer("Feed B", "2026-02-17T10:00:00Z")
-	defer srv2.Close()
-
-	feeds := []Feed{
-		{Name: "A", URL: srv1.URL},
-		{Name: "B", URL: srv2.URL},
-	}
-
-	posts, errs := FetchAllFeeds(context.Background(), feeds, 50, 5*time.Second)
-	if len(errs) != 0 {
-		t.Errorf("expected no errors, got %v", errs)
-	}
-	if len(posts) != 2 {
-		t.Fatalf("expected 2 posts, got %d", len(posts))
-	}
-	// Should be sorted newest first
-	if posts[0].FeedName != "B" {
-		t.Errorf("expected newest post from Feed B first, got %q", posts[0].FeedName)
-	}
-}
-
-func TestFetchAllFeeds_PartialFailure(t *testing.T) {
-	srv := newFeedServer("Good Feed", "2026-02-16T10:00:00Z")
-	defer srv.Close()
-	badSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(500)
-	}))
-	defer badSrv.Close()
-
-	feeds := []Feed{
-		{Name: "Good", URL: srv.URL},
-		{Name: "Bad", URL: badSrv.URL},
-	}
-
-	posts, errs := FetchAllFeeds(context.Background(), feeds, 50, 5*time.Second)
-	if len(errs) != 1 {
-		t.Errorf("expected 1 error, got %d", len(errs))
-	}
-	if len(posts) != 1 {
-		t.Errorf("expected 1 post from successful feed, got %d", len(posts))
-	}
-}
-
-func TestFetchAllFeeds_AllFail(t *testing.T) {
-	badSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(500)
-	}))
-	defer badSrv.Close()
-
-	feeds := []Feed{
-		{Name: "Bad1", URL: badSrv.URL},
-		{Name: "Bad2", URL: badSrv.URL + "/other"},
-	}
-
-	posts, errs := FetchAllFeeds(context.Background(), feeds, 50, 5*time.Second)
-	if len(errs) == 0 {
-		t.Error("expected errors, got none")
-	}
-	if len(posts) != 0 {
-		t.Errorf("expected 0 posts, got %d", len(posts))
-	}
-}
-
 func newFeedServer(title, pubDate string) *httptest.Server {
 	rss := fmt.Sprintf(`<?xml version="1.0"?>
 <rss version="2.0">
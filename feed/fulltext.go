@@ -0,0 +1,331 @@
+package feed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ContentCache persists distilled article bodies keyed by article URL plus a
+// content hash, so re-requesting GET /rss?fulltext=1 doesn't re-distill
+// pages whose content hasn't changed, and tracks each article's last-seen
+// ETag/Last-Modified so fetchArticleBody can send a conditional GET instead
+// of always resending the full page.
+type ContentCache struct {
+	// mu guards both the in-memory maps and the on-disk file they're
+	// mirrored to: Put and PutValidator hold it across the whole
+	// mutate-then-write sequence, not just the mutation, so concurrent
+	// writers (EnrichFeedPosts' per-post goroutines, concurrently refreshed
+	// feeds, and on-demand ?fulltext=1 requests can all write this same
+	// file at once) can't interleave their writes and have the
+	// later-mutating goroutine's os.WriteFile silently discard another
+	// goroutine's just-recorded entry or validator.
+	mu         sync.Mutex
+	path       string
+	entries    map[string]string
+	validators map[string]articleValidator
+}
+
+// articleValidator is the conditional-GET state fetchArticleBody records per
+// article URL after a successful fetch, mirroring FeedState's ETag/
+// LastModified/ContentHash fields for feed documents.
+type articleValidator struct {
+	ETag         string `yaml:"etag,omitempty"`
+	LastModified string `yaml:"last_modified,omitempty"`
+	Hash         string `yaml:"hash,omitempty"`
+}
+
+type contentCacheFile struct {
+	Entries    map[string]string           `yaml:"entries"`
+	Validators map[string]articleValidator `yaml:"validators,omitempty"`
+}
+
+// NewContentCache loads a ContentCache from path, which need not yet exist.
+func NewContentCache(path string) (*ContentCache, error) {
+	cf, err := loadContentCacheFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ContentCache{path: path, entries: cf.Entries, validators: cf.Validators}, nil
+}
+
+func loadContentCacheFile(path string) (contentCacheFile, error) {
+	empty := contentCacheFile{Entries: map[string]string{}, Validators: map[string]articleValidator{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return empty, nil
+		}
+		return contentCacheFile{}, err
+	}
+	if len(data) == 0 {
+		return empty, nil
+	}
+
+	var cf contentCacheFile
+	if err := yaml.Unmarshal(data, &cf); err != nil {
+		return contentCacheFile{}, err
+	}
+	if cf.Entries == nil {
+		cf.Entries = map[string]string{}
+	}
+	if cf.Validators == nil {
+		cf.Validators = map[string]articleValidator{}
+	}
+	return cf, nil
+}
+
+func cacheKey(articleURL, hash string) string {
+	return articleURL + "|" + hash
+}
+
+// Get returns the cached distilled body for articleURL, if its content still
+// hashes to hash.
+func (c *ContentCache) Get(articleURL, hash string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[cacheKey(articleURL, hash)]
+	return v, ok
+}
+
+// Validator returns the ETag/Last-Modified/hash recorded for articleURL's
+// last successful fetch, if any.
+func (c *ContentCache) Validator(articleURL string) (articleValidator, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.validators[articleURL]
+	return v, ok
+}
+
+// Put records the distilled body for articleURL at the given content hash
+// and persists the cache to disk.
+func (c *ContentCache) Put(articleURL, hash, body string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[cacheKey(articleURL, hash)] = body
+	return c.writeFile()
+}
+
+// PutValidator records articleURL's latest ETag/Last-Modified/hash and
+// persists the cache to disk.
+func (c *ContentCache) PutValidator(articleURL string, v articleValidator) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validators[articleURL] = v
+	return c.writeFile()
+}
+
+// writeFile persists c.entries/c.validators to disk. Callers must hold c.mu.
+func (c *ContentCache) writeFile() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(&contentCacheFile{Entries: c.entries, Validators: c.validators})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
+
+var (
+	scriptStyleRe = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	tagRe         = regexp.MustCompile(`(?s)<[^>]+>`)
+	whitespaceRe  = regexp.MustCompile(`\s+`)
+)
+
+// distillText reduces an HTML document down to its visible text: scripts and
+// stylesheets are dropped, remaining tags are stripped, and whitespace is
+// collapsed. It's a heuristic rather than a true readability algorithm, but
+// it's enough to turn a link-only feed item into something searchable.
+func distillText(body string) string {
+	stripped := scriptStyleRe.ReplaceAllString(body, " ")
+	stripped = tagRe.ReplaceAllString(stripped, " ")
+	stripped = html.UnescapeString(stripped)
+	return strings.TrimSpace(whitespaceRe.ReplaceAllString(stripped, " "))
+}
+
+// fetchArticleBody downloads articleURL, sending a conditional GET against
+// whatever ETag/Last-Modified cache has on record for it from a previous
+// fetch. If the server answers 304, notModified is true and body is nil —
+// the caller should look up the already-cached text by the returned hash
+// rather than re-distilling. On a fresh 200, the new validators are recorded
+// in cache for next time. cache may be nil, in which case every fetch is
+// unconditional.
+func fetchArticleBody(ctx context.Context, client *http.Client, articleURL string, cache *ContentCache) (body []byte, hash string, notModified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, articleURL, nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	var prev articleValidator
+	if cache != nil {
+		prev, _ = cache.Validator(articleURL)
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, prev.Hash, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", false, fmt.Errorf("fetch article %s: unexpected status %d", articleURL, resp.StatusCode)
+	}
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", false, err
+	}
+	sum := sha256.Sum256(body)
+	hash = hex.EncodeToString(sum[:])
+
+	if cache != nil {
+		cache.PutValidator(articleURL, articleValidator{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Hash:         hash,
+		})
+	}
+	return body, hash, false, nil
+}
+
+// FetchArticleText downloads articleURL and returns a distilled plain-text
+// rendering of the page. A conditional GET against the article's last-seen
+// validators (see fetchArticleBody) lets an unchanged origin answer 304
+// instead of resending the page, and the distilled text itself is cached by
+// content hash so it's never redundantly re-distilled either.
+func FetchArticleText(ctx context.Context, client *http.Client, articleURL string, cache *ContentCache) (string, error) {
+	body, hash, notModified, err := fetchArticleBody(ctx, client, articleURL, cache)
+	if err != nil {
+		return "", err
+	}
+	if notModified {
+		if cached, ok := cache.Get(articleURL, hash); ok {
+			return cached, nil
+		}
+		// The recorded validator has no matching cached text (e.g. a prior
+		// Put failed to persist) — fall back to an unconditional re-fetch.
+		body, hash, _, err = fetchArticleBody(ctx, client, articleURL, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if cache != nil {
+		if cached, ok := cache.Get(articleURL, hash); ok {
+			return cached, nil
+		}
+	}
+
+	text := distillText(string(body))
+	if cache != nil {
+		if err := cache.Put(articleURL, hash, text); err != nil {
+			return text, err
+		}
+	}
+	return text, nil
+}
+
+// FetchArticleContent downloads articleURL and extracts its main content:
+// via the CSS selector in selector, when non-empty and present in the page,
+// falling back to the generic distillText heuristic over the whole body
+// otherwise. Cache entries (and conditional-GET reuse) are keyed additionally
+// by selector, so changing a feed's ScraperRules doesn't serve a stale
+// extraction for content the origin reports as unchanged.
+func FetchArticleContent(ctx context.Context, client *http.Client, articleURL, selector string, cache *ContentCache) (string, error) {
+	body, hash, notModified, err := fetchArticleBody(ctx, client, articleURL, cache)
+	if err != nil {
+		return "", err
+	}
+
+	cacheKey := articleURL
+	if selector != "" {
+		cacheKey = articleURL + "|" + selector
+	}
+
+	if notModified {
+		if cached, ok := cache.Get(cacheKey, hash); ok {
+			return cached, nil
+		}
+		// Unchanged per the origin, but no cached text for this selector yet
+		// (e.g. ScraperRules just changed) — re-fetch unconditionally so
+		// there's a body to extract from.
+		body, hash, _, err = fetchArticleBody(ctx, client, articleURL, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if cache != nil {
+		if cached, ok := cache.Get(cacheKey, hash); ok {
+			return cached, nil
+		}
+	}
+
+	text := distillText(string(body))
+	if selector != "" {
+		if scoped, ok := extractBySelector(string(body), selector); ok {
+			text = distillText(scoped)
+		}
+	}
+
+	if cache != nil {
+		if err := cache.Put(cacheKey, hash, text); err != nil {
+			return text, err
+		}
+	}
+	return text, nil
+}
+
+// EnrichWithFullText fills in Content for posts whose feed item carried no
+// body, by fetching and distilling each post's linked article. Fetches run
+// concurrently across the shared processingSem pool (see processor.go), and
+// perArticleTimeout bounds each individual fetch so one slow article can't
+// stall the rest of the batch.
+func EnrichWithFullText(ctx context.Context, posts []Post, cache *ContentCache, perArticleTimeout time.Duration) {
+	var wg sync.WaitGroup
+	client := &http.Client{Timeout: perArticleTimeout}
+
+	for i := range posts {
+		if posts[i].Content != "" || posts[i].URL == "" {
+			continue
+		}
+		wg.Add(1)
+		processingSem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-processingSem }()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, perArticleTimeout)
+			defer cancel()
+
+			if text, err := FetchArticleText(fetchCtx, client, posts[i].URL, cache); err == nil {
+				posts[i].Content = text
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,110 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const sampleArticleHTML = `<html><head><style>body{color:red}</style></head>
+<body><script>track();</script><h1>Headline</h1><p>First &amp; only paragraph.</p></body></html>`
+
+func TestDistillText_StripsTagsAndScripts(t *testing.T) {
+	got := distillText(sampleArticleHTML)
+	if got != "Headline First & only paragraph." {
+		t.Errorf("unexpected distilled text: %q", got)
+	}
+}
+
+func TestFetchArticleText_CachesByURLAndHash(t *testing.T) {
+	const etag = `"stable-etag"`
+	fullSends := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullSends++
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, sampleArticleHTML)
+	}))
+	defer srv.Close()
+
+	cache, err := NewContentCache(filepath.Join(t.TempDir(), "fulltext-cache.yaml"))
+	if err != nil {
+		t.Fatalf("NewContentCache: %v", err)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	text1, err := FetchArticleText(context.Background(), client, srv.URL, cache)
+	if err != nil {
+		t.Fatalf("FetchArticleText: %v", err)
+	}
+	text2, err := FetchArticleText(context.Background(), client, srv.URL, cache)
+	if err != nil {
+		t.Fatalf("FetchArticleText: %v", err)
+	}
+	if text1 != text2 {
+		t.Errorf("expected cached text to match, got %q vs %q", text1, text2)
+	}
+	if fullSends != 1 {
+		t.Errorf("expected the origin to send the full article exactly once, got %d", fullSends)
+	}
+}
+
+func TestContentCache_ConcurrentPutsAllPersist(t *testing.T) {
+	cache, err := NewContentCache(filepath.Join(t.TempDir(), "fulltext-cache.yaml"))
+	if err != nil {
+		t.Fatalf("NewContentCache: %v", err)
+	}
+
+	const n = 8
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("https://example.com/article%d", i)
+			hash := fmt.Sprintf("hash%d", i)
+			if err := cache.Put(url, hash, fmt.Sprintf("body %d", i)); err != nil {
+				t.Errorf("Put failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		url := fmt.Sprintf("https://example.com/article%d", i)
+		hash := fmt.Sprintf("hash%d", i)
+		want := fmt.Sprintf("body %d", i)
+		if got, ok := cache.Get(url, hash); !ok || got != want {
+			t.Errorf("article %d: Get = %q, %v; want %q, true (concurrent Put lost)", i, got, ok, want)
+		}
+	}
+}
+
+func TestEnrichWithFullText_FillsMissingContentOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sampleArticleHTML)
+	}))
+	defer srv.Close()
+
+	posts := []Post{
+		{Title: "No body", URL: srv.URL},
+		{Title: "Already has body", URL: srv.URL, Content: "existing content"},
+	}
+
+	EnrichWithFullText(context.Background(), posts, nil, 5*time.Second)
+
+	if posts[0].Content == "" {
+		t.Error("expected first post's content to be enriched")
+	}
+	if posts[1].Content != "existing content" {
+		t.Errorf("expected second post's existing content to be left alone, got %q", posts[1].Content)
+	}
+}
@@ -0,0 +1,109 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+type opmlHead struct {
+	Title string `xml:"title"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseOPML parses an OPML 2.0 document into feeds. Nested folder outlines
+// (outlines with no xmlUrl) become each descendant feed's Categories, so a
+// feed filed three folders deep ends up tagged with all three names.
+func ParseOPML(r io.Reader) ([]Feed, error) {
+	var doc opmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var feeds []Feed
+	collectOutlines(doc.Body.Outlines, nil, &feeds)
+	return feeds, nil
+}
+
+func collectOutlines(outlines []opmlOutline, categories []string, feeds *[]Feed) {
+	for _, o := range outlines {
+		if o.XMLURL != "" {
+			name := o.Title
+			if name == "" {
+				name = o.Text
+			}
+			*feeds = append(*feeds, Feed{
+				Name:       name,
+				URL:        o.XMLURL,
+				Categories: append([]string(nil), categories...),
+			})
+			continue
+		}
+
+		folder := o.Title
+		if folder == "" {
+			folder = o.Text
+		}
+		nested := categories
+		if folder != "" {
+			nested = append(append([]string(nil), categories...), folder)
+		}
+		collectOutlines(o.Outlines, nested, feeds)
+	}
+}
+
+// WriteOPML writes feeds as an OPML 2.0 document, grouping each feed under an
+// outline folder named after its first category. Feeds with no category are
+// written at the top level.
+func WriteOPML(w io.Writer, feeds []Feed) error {
+	doc := opmlDocument{Version: "2.0", Head: opmlHead{Title: "lexora feed export"}}
+
+	byCategory := map[string][]Feed{}
+	var order []string
+	for _, f := range feeds {
+		if len(f.Categories) == 0 {
+			doc.Body.Outlines = append(doc.Body.Outlines, feedOutline(f))
+			continue
+		}
+		cat := f.Categories[0]
+		if _, ok := byCategory[cat]; !ok {
+			order = append(order, cat)
+		}
+		byCategory[cat] = append(byCategory[cat], f)
+	}
+	for _, cat := range order {
+		group := opmlOutline{Text: cat, Title: cat}
+		for _, f := range byCategory[cat] {
+			group.Outlines = append(group.Outlines, feedOutline(f))
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, group)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(&doc)
+}
+
+func feedOutline(f Feed) opmlOutline {
+	return opmlOutline{Text: f.Name, Title: f.Name, Type: "rss", XMLURL: f.URL}
+}
@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const sampleOPML = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Feeds</title></head>
+  <body>
+    <outline text="Hacker News" type="rss" xmlUrl="https://news.ycombinator.com/rss"/>
+    <outline text="Tech">
+      <outline text="Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+    </outline>
+  </body>
+</opml>`
+
+func TestParseOPML_FlatAndNested(t *testing.T) {
+	feeds, err := ParseOPML(strings.NewReader(sampleOPML))
+	if err != nil {
+		t.Fatalf("ParseOPML failed: %v", err)
+	}
+	if len(feeds) != 2 {
+		t.Fatalf("expected 2 feeds, got %d", len(feeds))
+	}
+	if feeds[0].Name != "Hacker News" || feeds[0].URL != "https://news.ycombinator.com/rss" {
+		t.Errorf("unexpected first feed: %+v", feeds[0])
+	}
+	if len(feeds[0].Categories) != 0 {
+		t.Errorf("expected top-level feed to have no categories, got %v", feeds[0].Categories)
+	}
+	if feeds[1].Name != "Go Blog" || feeds[1].URL != "https://go.dev/blog/feed.atom" {
+		t.Errorf("unexpected second feed: %+v", feeds[1])
+	}
+	if len(feeds[1].Categories) != 1 || feeds[1].Categories[0] != "Tech" {
+		t.Errorf("expected nested feed to be categorized as Tech, got %v", feeds[1].Categories)
+	}
+}
+
+func TestParseOPML_Invalid(t *testing.T) {
+	_, err := ParseOPML(strings.NewReader("not xml at all"))
+	if err == nil {
+		t.Error("expected error for invalid OPML, got nil")
+	}
+}
+
+func TestWriteOPML_RoundTrips(t *testing.T) {
+	feeds := []Feed{
+		{Name: "Hacker News", URL: "https://news.ycombinator.com/rss"},
+		{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom", Categories: []string{"Tech"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOPML(&buf, feeds); err != nil {
+		t.Fatalf("WriteOPML failed: %v", err)
+	}
+
+	roundTripped, err := ParseOPML(&buf)
+	if err != nil {
+		t.Fatalf("ParseOPML of written OPML failed: %v", err)
+	}
+	if len(roundTripped) != 2 {
+		t.Fatalf("expected 2 feeds after round-trip, got %d", len(roundTripped))
+	}
+	if roundTripped[1].Name != "Go Blog" || roundTripped[1].Categories[0] != "Tech" {
+		t.Errorf("category grouping lost in round-trip: %+v", roundTripped[1])
+	}
+}
@@ -0,0 +1,114 @@
+package feed
+
+import (
+	"sort"
+	"sync"
+)
+
+type storeEntry struct {
+	feed  Feed
+	posts []Post
+	err   error
+}
+
+// Store is an in-memory, thread-safe index of the most recently fetched
+// posts for every configured feed, keyed by feed URL. A Scheduler keeps it up
+// to date in the background so request handlers can read from it without
+// blocking on upstream fetches.
+type Store struct {
+	mu      sync.RWMutex
+	entries map[string]storeEntry
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{entries: make(map[string]storeEntry)}
+}
+
+// UpdatePosts replaces the cached posts for fd after a successful fetch and
+// clears any previously recorded error.
+func (s *Store) UpdatePosts(fd Feed, posts []Post) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[fd.URL] = storeEntry{feed: fd, posts: posts}
+}
+
+// SetError records the most recent fetch error for fd without discarding its
+// previously cached posts, so a single slow or failing upstream doesn't blank
+// out results that were already served successfully.
+func (s *Store) SetError(fd Feed, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := s.entries[fd.URL]
+	entry.feed = fd
+	entry.err = err
+	s.entries[fd.URL] = entry
+}
+
+// ByDate returns every cached post across all feeds, newest first.
+func (s *Store) ByDate() []Post {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var all []Post
+	for _, e := range s.entries {
+		all = append(all, e.posts...)
+	}
+	sortByDateDesc(all)
+	return all
+}
+
+// ByFeed returns the cached posts for a single feed, identified by name.
+func (s *Store) ByFeed(name string) []Post {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, e := range s.entries {
+		if e.feed.Name == name {
+			return append([]Post(nil), e.posts...)
+		}
+	}
+	return nil
+}
+
+// ByCategory returns the cached posts belonging to feeds tagged with
+// category, newest first.
+func (s *Store) ByCategory(category string) []Post {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var posts []Post
+	for _, e := range s.entries {
+		if e.feed.HasCategory(category) {
+			posts = append(posts, e.posts...)
+		}
+	}
+	sortByDateDesc(posts)
+	return posts
+}
+
+// Errors returns the most recent fetch error per feed that currently has one.
+func (s *Store) Errors() []FeedError {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var errs []FeedError
+	for _, e := range s.entries {
+		if e.err != nil {
+			errs = append(errs, FeedError{FeedName: e.feed.Name, URL: e.feed.URL, Err: e.err})
+		}
+	}
+	return errs
+}
+
+// sortByDateDesc orders posts newest first, breaking ties on GUID so the
+// ordering is deterministic across calls — keyset pagination cursors depend
+// on a stable total order.
+func sortByDateDesc(posts []Post) {
+	sort.Slice(posts, func(i, j int) bool {
+		if !posts[i].PublishedAt.Equal(posts[j].PublishedAt) {
+			return posts[i].PublishedAt.After(posts[j].PublishedAt)
+		}
+		return posts[i].GUID > posts[j].GUID
+	})
+}
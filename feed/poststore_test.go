@@ -0,0 +1,68 @@
+package feed
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStore_UpdatePostsAndByDate(t *testing.T) {
+	s := NewStore()
+	a := Feed{Name: "A", URL: "https://a.com"}
+	b := Feed{Name: "B", URL: "https://b.com"}
+
+	s.UpdatePosts(a, []Post{{FeedName: "A", PublishedAt: time.Unix(100, 0)}})
+	s.UpdatePosts(b, []Post{{FeedName: "B", PublishedAt: time.Unix(200, 0)}})
+
+	posts := s.ByDate()
+	if len(posts) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(posts))
+	}
+	if posts[0].FeedName != "B" {
+		t.Errorf("expected newest post (B) first, got %q", posts[0].FeedName)
+	}
+}
+
+func TestStore_ByFeed(t *testing.T) {
+	s := NewStore()
+	a := Feed{Name: "A", URL: "https://a.com"}
+	s.UpdatePosts(a, []Post{{FeedName: "A", Title: "Hello"}})
+
+	posts := s.ByFeed("A")
+	if len(posts) != 1 || posts[0].Title != "Hello" {
+		t.Errorf("unexpected posts for feed A: %+v", posts)
+	}
+	if posts := s.ByFeed("Missing"); posts != nil {
+		t.Errorf("expected nil for unknown feed, got %+v", posts)
+	}
+}
+
+func TestStore_ByCategory(t *testing.T) {
+	s := NewStore()
+	tech := Feed{Name: "Tech", URL: "https://tech.com", Categories: []string{"tech"}}
+	news := Feed{Name: "News", URL: "https://news.com", Categories: []string{"news"}}
+	s.UpdatePosts(tech, []Post{{FeedName: "Tech"}})
+	s.UpdatePosts(news, []Post{{FeedName: "News"}})
+
+	posts := s.ByCategory("tech")
+	if len(posts) != 1 || posts[0].FeedName != "Tech" {
+		t.Errorf("expected only Tech posts, got %+v", posts)
+	}
+}
+
+func TestStore_SetErrorPreservesPreviousPosts(t *testing.T) {
+	s := NewStore()
+	a := Feed{Name: "A", URL: "https://a.com"}
+	s.UpdatePosts(a, []Post{{FeedName: "A", Title: "Old"}})
+
+	s.SetError(a, errors.New("boom"))
+
+	posts := s.ByFeed("A")
+	if len(posts) != 1 || posts[0].Title != "Old" {
+		t.Errorf("expected previous posts to survive an error, got %+v", posts)
+	}
+	errs := s.Errors()
+	if len(errs) != 1 || errs[0].FeedName != "A" {
+		t.Errorf("expected 1 error for feed A, got %+v", errs)
+	}
+}
@@ -0,0 +1,119 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// userAgent identifies this service to upstream feeds and the article pages
+// it scrapes for full content, so an operator who sees it in their access
+// logs can tell what's making the requests.
+const userAgent = "lexora-rss/1.0"
+
+// processingConcurrency bounds how many article fetches — fetch-time
+// full-content scraping and on-demand ?fulltext=1 distillation alike — can
+// run at once across the whole process, so a burst of feeds with
+// FullContent enabled doesn't overwhelm their origin sites.
+const processingConcurrency = 5
+
+// processingSem is shared by EnrichWithFullText and EnrichFeedPosts so the
+// concurrency cap is global rather than scoped to a single call.
+var processingSem = make(chan struct{}, processingConcurrency)
+
+// ApplyRewriteRules runs each rule's regex replace against post's Title and
+// Content, in order. A rule whose Pattern doesn't compile is skipped rather
+// than erroring, so one bad rule doesn't drop the whole post.
+func ApplyRewriteRules(post Post, rules []RewriteRule) Post {
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		post.Title = re.ReplaceAllString(post.Title, rule.Replace)
+		post.Content = re.ReplaceAllString(post.Content, rule.Replace)
+	}
+	return post
+}
+
+// extractBySelector pulls the inner HTML of the first element matching a
+// simple CSS selector — a bare tag name, a .class, or a #id — out of body.
+// Like distillText, it's a heuristic rather than a full CSS engine: just
+// enough to scope extraction to an article's main content when a feed
+// configures it, not a spec-complete selector implementation.
+func extractBySelector(body, selector string) (string, bool) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return "", false
+	}
+
+	if !strings.HasPrefix(selector, ".") && !strings.HasPrefix(selector, "#") {
+		re := regexp.MustCompile(fmt.Sprintf(`(?is)<%s[^>]*>(.*?)</%s>`, regexp.QuoteMeta(selector), regexp.QuoteMeta(selector)))
+		m := re.FindStringSubmatch(body)
+		if m == nil {
+			return "", false
+		}
+		return m[1], true
+	}
+
+	var attrPattern string
+	if rest, ok := strings.CutPrefix(selector, "."); ok {
+		attrPattern = fmt.Sprintf(`class=["'][^"']*\b%s\b[^"']*["']`, regexp.QuoteMeta(rest))
+	} else {
+		rest := strings.TrimPrefix(selector, "#")
+		attrPattern = fmt.Sprintf(`id=["']%s["']`, regexp.QuoteMeta(rest))
+	}
+
+	openTagRe := regexp.MustCompile(fmt.Sprintf(`(?is)<([a-zA-Z0-9]+)[^>]*%s[^>]*>`, attrPattern))
+	loc := openTagRe.FindStringSubmatchIndex(body)
+	if loc == nil {
+		return "", false
+	}
+	tag := body[loc[2]:loc[3]]
+	rest := body[loc[1]:]
+	closeIdx := strings.Index(strings.ToLower(rest), "</"+strings.ToLower(tag)+">")
+	if closeIdx == -1 {
+		return "", false
+	}
+	return rest[:closeIdx], true
+}
+
+// EnrichFeedPosts applies fd's FullContent/ScraperRules/RewriteRules
+// configuration to its freshly fetched posts. When FullContent is set, each
+// post's article URL is scraped for its main content (scoped by
+// ScraperRules if set) and Content is replaced; fetches run across the
+// shared processingSem pool. RewriteRules then run over every post
+// regardless of FullContent.
+func EnrichFeedPosts(ctx context.Context, fd Feed, posts []Post, cache *ContentCache, perArticleTimeout time.Duration) {
+	if fd.FullContent {
+		client := &http.Client{Timeout: perArticleTimeout}
+		var wg sync.WaitGroup
+		for i := range posts {
+			if posts[i].URL == "" {
+				continue
+			}
+			wg.Add(1)
+			processingSem <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-processingSem }()
+
+				fetchCtx, cancel := context.WithTimeout(ctx, perArticleTimeout)
+				defer cancel()
+
+				if text, err := FetchArticleContent(fetchCtx, client, posts[i].URL, fd.ScraperRules, cache); err == nil {
+					posts[i].Content = text
+				}
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	for i := range posts {
+		posts[i] = ApplyRewriteRules(posts[i], fd.RewriteRules)
+	}
+}
@@ -0,0 +1,91 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestApplyRewriteRules_ReplacesAcrossTitleAndContent(t *testing.T) {
+	post := Post{Title: "[Sponsored] Big News", Content: "Brought to you by Acme. Big News continues."}
+	rules := []RewriteRule{{Pattern: `^\[Sponsored\] `, Replace: ""}, {Pattern: `Acme`, Replace: "Acme Corp"}}
+
+	got := ApplyRewriteRules(post, rules)
+
+	if got.Title != "Big News" {
+		t.Errorf("unexpected title: %q", got.Title)
+	}
+	if got.Content != "Brought to you by Acme Corp. Big News continues." {
+		t.Errorf("unexpected content: %q", got.Content)
+	}
+}
+
+func TestApplyRewriteRules_SkipsInvalidPattern(t *testing.T) {
+	post := Post{Title: "Title", Content: "Content"}
+	rules := []RewriteRule{{Pattern: "(", Replace: "x"}}
+
+	got := ApplyRewriteRules(post, rules)
+
+	if got.Title != "Title" || got.Content != "Content" {
+		t.Errorf("expected post to be left unchanged, got: %+v", got)
+	}
+}
+
+func TestExtractBySelector_MatchesTagClassAndID(t *testing.T) {
+	body := `<html><body><nav>skip</nav><article class="post body"><p>Main text</p></article></body></html>`
+
+	tagMatch, ok := extractBySelector(body, "article")
+	if !ok || tagMatch != `<p>Main text</p>` {
+		t.Errorf("tag selector: got %q, ok=%v", tagMatch, ok)
+	}
+
+	classMatch, ok := extractBySelector(body, ".post")
+	if !ok || classMatch != `<p>Main text</p>` {
+		t.Errorf("class selector: got %q, ok=%v", classMatch, ok)
+	}
+
+	body2 := `<div id="main-content"><p>Content here</p></div>`
+	idMatch, ok := extractBySelector(body2, "#main-content")
+	if !ok || idMatch != `<p>Content here</p>` {
+		t.Errorf("id selector: got %q, ok=%v", idMatch, ok)
+	}
+}
+
+func TestExtractBySelector_NoMatchReturnsFalse(t *testing.T) {
+	if _, ok := extractBySelector(`<div>no article here</div>`, "article"); ok {
+		t.Error("expected no match for a selector absent from the body")
+	}
+}
+
+func TestEnrichFeedPosts_ScrapesOnlyWhenFullContentEnabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><article>Scraped body</article></body></html>`)
+	}))
+	defer srv.Close()
+
+	enabled := []Post{{Title: "Post", URL: srv.URL}}
+	EnrichFeedPosts(context.Background(), Feed{FullContent: true}, enabled, nil, 5*time.Second)
+	if enabled[0].Content != "Scraped body" {
+		t.Errorf("expected scraped content, got %q", enabled[0].Content)
+	}
+
+	disabled := []Post{{Title: "Post", URL: srv.URL}}
+	EnrichFeedPosts(context.Background(), Feed{}, disabled, nil, 5*time.Second)
+	if disabled[0].Content != "" {
+		t.Errorf("expected content left untouched when FullContent is false, got %q", disabled[0].Content)
+	}
+}
+
+func TestEnrichFeedPosts_AppliesRewriteRulesRegardlessOfFullContent(t *testing.T) {
+	posts := []Post{{Title: "[Ad] Headline"}}
+	fd := Feed{RewriteRules: []RewriteRule{{Pattern: `^\[Ad\] `, Replace: ""}}}
+
+	EnrichFeedPosts(context.Background(), fd, posts, nil, 5*time.Second)
+
+	if posts[0].Title != "Headline" {
+		t.Errorf("expected rewrite rule to apply, got title %q", posts[0].Title)
+	}
+}
@@ -0,0 +1,249 @@
+// Package render serves an aggregated []feed.Post list as a single combined
+// Atom 1.0 feed, RSS 2.0 channel, or JSON Feed 1.1 document, for clients that
+// want to consume /rss through their own feed reader rather than as the plain
+// JSON post list.
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"personal-kb/services/rss/config"
+	"personal-kb/services/rss/feed"
+)
+
+const feedTitle = "lexora aggregated feed"
+
+// StylesheetPI is the processing instruction pointing browsers at the
+// bundled XSL so the combined feed renders as readable HTML instead of raw
+// XML.
+const StylesheetPI = `<?xml-stylesheet type="text/xsl" href="/feed.xsl"?>` + "\n"
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string     `xml:"title"`
+	Link    atomLink   `xml:"link"`
+	ID      string     `xml:"id"`
+	Updated string     `xml:"updated"`
+	Author  atomAuthor `xml:"author"`
+	Summary string     `xml:"summary,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// RenderAtom writes posts as a single combined Atom 1.0 feed to w. Each
+// entry's id, and the feed's own id, are stable tag: URIs (RFC 4151) derived
+// from cfg.Host, the range start date, and the post's feed+URL, so the same
+// post produces the same id across requests that share a range start date.
+// With a zero from, today's date is used, so those ids change daily.
+func RenderAtom(w io.Writer, cfg *config.Config, posts []feed.Post, from, to time.Time) error {
+	startDate := tagDate(from)
+	doc := atomFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		Title:   feedTitle,
+		ID:      tagURI(cfg.Host, startDate, "combined-feed"),
+		Updated: feedUpdated(posts).Format(time.RFC3339),
+	}
+	for _, p := range posts {
+		doc.Entries = append(doc.Entries, atomEntry{
+			Title:   p.Title,
+			Link:    atomLink{Href: p.URL},
+			ID:      tagURI(cfg.Host, startDate, p.FeedName+"|"+p.URL),
+			Updated: p.PublishedAt.UTC().Format(time.RFC3339),
+			Author:  atomAuthor{Name: p.FeedName},
+			Summary: p.Summary,
+		})
+	}
+	return writeXML(w, &doc)
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Author      string  `xml:"author,omitempty"`
+	Description string  `xml:"description,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RenderRSS writes posts as a single combined RSS 2.0 channel to w, with the
+// same tag: URI scheme as RenderAtom used for each item's guid.
+func RenderRSS(w io.Writer, cfg *config.Config, posts []feed.Post, from, to time.Time) error {
+	startDate := tagDate(from)
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feedTitle,
+			Link:          "http://" + cfg.Host,
+			Description:   feedTitle,
+			LastBuildDate: feedUpdated(posts).Format(time.RFC1123Z),
+		},
+	}
+	for _, p := range posts {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       p.Title,
+			Link:        p.URL,
+			GUID:        rssGUID{IsPermaLink: "false", Value: tagURI(cfg.Host, startDate, p.FeedName+"|"+p.URL)},
+			PubDate:     p.PublishedAt.UTC().Format(time.RFC1123Z),
+			Author:      p.FeedName,
+			Description: p.Summary,
+		})
+	}
+	return writeXML(w, &doc)
+}
+
+// jsonFeedDocument is the JSON Feed 1.1 (https://www.jsonfeed.org/version/1.1/)
+// document shape for the combined feed.
+type jsonFeedDocument struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string          `json:"id"`
+	URL           string          `json:"url,omitempty"`
+	Title         string          `json:"title,omitempty"`
+	ContentText   string          `json:"content_text,omitempty"`
+	DatePublished time.Time       `json:"date_published,omitempty"`
+	Author        *jsonFeedAuthor `json:"author,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// RenderJSONFeed writes posts as a single combined JSON Feed 1.1 document to
+// w, using the same tag: URI scheme as RenderAtom/RenderRSS for each item's
+// id.
+func RenderJSONFeed(w io.Writer, cfg *config.Config, posts []feed.Post, from, to time.Time) error {
+	startDate := tagDate(from)
+	doc := jsonFeedDocument{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feedTitle,
+		HomePageURL: "http://" + cfg.Host,
+		FeedURL:     "http://" + cfg.Host + "/rss?format=jsonfeed",
+	}
+	for _, p := range posts {
+		content := p.Content
+		if content == "" {
+			content = p.Summary
+		}
+		var author *jsonFeedAuthor
+		if p.FeedName != "" {
+			author = &jsonFeedAuthor{Name: p.FeedName}
+		}
+		doc.Items = append(doc.Items, jsonFeedItem{
+			ID:            tagURI(cfg.Host, startDate, p.FeedName+"|"+p.URL),
+			URL:           p.URL,
+			Title:         p.Title,
+			ContentText:   content,
+			DatePublished: p.PublishedAt.UTC(),
+			Author:        author,
+		})
+	}
+	if doc.Items == nil {
+		doc.Items = []jsonFeedItem{}
+	}
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// LastModified returns the most recent PublishedAt across posts — the same
+// timestamp used for <lastBuildDate>/<updated> — so callers can mirror it in
+// an HTTP Last-Modified header.
+func LastModified(posts []feed.Post) time.Time {
+	return feedUpdated(posts)
+}
+
+// ETag computes a stable strong ETag for a rendered feed document by hashing
+// each post's tag URI, so the value only changes when the entry set or the
+// range start date embedded in those URIs does.
+func ETag(cfg *config.Config, posts []feed.Post, from time.Time) string {
+	startDate := tagDate(from)
+	h := sha256.New()
+	for _, p := range posts {
+		io.WriteString(h, tagURI(cfg.Host, startDate, p.FeedName+"|"+p.URL))
+		h.Write([]byte{0})
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, StylesheetPI); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+// feedUpdated returns the most recent PublishedAt across posts, falling back
+// to now when there are none.
+func feedUpdated(posts []feed.Post) time.Time {
+	var latest time.Time
+	for _, p := range posts {
+		if p.PublishedAt.After(latest) {
+			latest = p.PublishedAt
+		}
+	}
+	if latest.IsZero() {
+		return time.Now().UTC()
+	}
+	return latest
+}
+
+func tagDate(from time.Time) string {
+	if from.IsZero() {
+		return time.Now().UTC().Format("2006-01-02")
+	}
+	return from.UTC().Format("2006-01-02")
+}
+
+// tagURI builds a stable tag: URI (RFC 4151) from host, date, and seed.
+func tagURI(host, date, seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return fmt.Sprintf("tag:%s,%s:%s", host, date, hex.EncodeToString(sum[:])[:16])
+}
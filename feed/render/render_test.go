@@ -0,0 +1,147 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"personal-kb/services/rss/config"
+	"personal-kb/services/rss/feed"
+)
+
+func samplePosts() []feed.Post {
+	return []feed.Post{
+		{
+			FeedName:    "Hacker News",
+			Title:       "Post One",
+			URL:         "https://example.com/1",
+			PublishedAt: time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC),
+			Summary:     "First post",
+		},
+		{
+			FeedName:    "Go Blog",
+			Title:       "Post Two",
+			URL:         "https://example.com/2",
+			PublishedAt: time.Date(2026, 2, 15, 9, 0, 0, 0, time.UTC),
+			Summary:     "Second post",
+		},
+	}
+}
+
+func TestRenderAtom_RoundTripsThroughGofeed(t *testing.T) {
+	cfg := &config.Config{Host: "lexora.example"}
+	posts := samplePosts()
+
+	var buf bytes.Buffer
+	if err := RenderAtom(&buf, cfg, posts, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("RenderAtom: %v", err)
+	}
+
+	parsed, err := gofeed.NewParser().ParseString(buf.String())
+	if err != nil {
+		t.Fatalf("gofeed failed to parse rendered Atom feed: %v", err)
+	}
+	if len(parsed.Items) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(parsed.Items))
+	}
+	if parsed.Items[0].Title != "Post One" || parsed.Items[0].Link != "https://example.com/1" {
+		t.Errorf("unexpected first entry: %+v", parsed.Items[0])
+	}
+	if parsed.Items[0].Author == nil || parsed.Items[0].Author.Name != "Hacker News" {
+		t.Errorf("expected first entry author to be the source feed name, got %+v", parsed.Items[0].Author)
+	}
+}
+
+func TestRenderRSS_RoundTripsThroughGofeed(t *testing.T) {
+	cfg := &config.Config{Host: "lexora.example"}
+	posts := samplePosts()
+
+	var buf bytes.Buffer
+	if err := RenderRSS(&buf, cfg, posts, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("RenderRSS: %v", err)
+	}
+
+	parsed, err := gofeed.NewParser().ParseString(buf.String())
+	if err != nil {
+		t.Fatalf("gofeed failed to parse rendered RSS feed: %v", err)
+	}
+	if len(parsed.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(parsed.Items))
+	}
+	if parsed.Items[0].Title != "Post One" || parsed.Items[0].Link != "https://example.com/1" {
+		t.Errorf("unexpected first item: %+v", parsed.Items[0])
+	}
+}
+
+func TestRenderJSONFeed_RoundTrips(t *testing.T) {
+	cfg := &config.Config{Host: "lexora.example"}
+	posts := samplePosts()
+
+	var buf bytes.Buffer
+	if err := RenderJSONFeed(&buf, cfg, posts, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("RenderJSONFeed: %v", err)
+	}
+
+	var doc jsonFeedDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to unmarshal rendered JSON Feed: %v", err)
+	}
+	if doc.Version != "https://jsonfeed.org/version/1.1" {
+		t.Errorf("unexpected version: %q", doc.Version)
+	}
+	if len(doc.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(doc.Items))
+	}
+	if doc.Items[0].Title != "Post One" || doc.Items[0].URL != "https://example.com/1" {
+		t.Errorf("unexpected first item: %+v", doc.Items[0])
+	}
+	if doc.Items[0].ID == "" {
+		t.Error("expected a non-empty stable id")
+	}
+}
+
+func TestETag_StableAcrossCallsChangesWithPosts(t *testing.T) {
+	cfg := &config.Config{Host: "lexora.example"}
+	posts := samplePosts()
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	e1 := ETag(cfg, posts, from)
+	e2 := ETag(cfg, posts, from)
+	if e1 != e2 {
+		t.Errorf("expected identical input to produce the same ETag, got %q vs %q", e1, e2)
+	}
+
+	fewer := posts[:1]
+	e3 := ETag(cfg, fewer, from)
+	if e3 == e1 {
+		t.Error("expected a different entry set to change the ETag")
+	}
+}
+
+func TestLastModified_UsesNewestPost(t *testing.T) {
+	posts := samplePosts()
+	got := LastModified(posts)
+	if !got.Equal(posts[0].PublishedAt) {
+		t.Errorf("LastModified = %v, want %v", got, posts[0].PublishedAt)
+	}
+}
+
+func TestRenderAtom_StableEntryIDs(t *testing.T) {
+	cfg := &config.Config{Host: "lexora.example"}
+	posts := samplePosts()
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var buf1, buf2 bytes.Buffer
+	if err := RenderAtom(&buf1, cfg, posts, from, time.Time{}); err != nil {
+		t.Fatalf("RenderAtom: %v", err)
+	}
+	if err := RenderAtom(&buf2, cfg, posts, from, time.Time{}); err != nil {
+		t.Fatalf("RenderAtom: %v", err)
+	}
+	if buf1.String() != buf2.String() {
+		t.Error("expected identical input to produce identical output (stable entry ids)")
+	}
+}
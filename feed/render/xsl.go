@@ -0,0 +1,42 @@
+package render
+
+// XSL is a small bundled stylesheet that renders the combined Atom/RSS feed
+// as a readable HTML page when opened directly in a browser, instead of raw
+// XML. It's intentionally minimal — title, link, and per-entry summary.
+const XSL = `<?xml version="1.0" encoding="UTF-8"?>
+<xsl:stylesheet version="1.0"
+    xmlns:xsl="http://www.w3.org/1999/XSL/Transform"
+    xmlns:atom="http://www.w3.org/2005/Atom">
+  <xsl:output method="html" encoding="UTF-8" indent="yes"/>
+  <xsl:template match="/rss">
+    <html>
+      <head><title><xsl:value-of select="channel/title"/></title></head>
+      <body>
+        <h1><xsl:value-of select="channel/title"/></h1>
+        <xsl:for-each select="channel/item">
+          <article>
+            <h2><a href="{link}"><xsl:value-of select="title"/></a></h2>
+            <p><xsl:value-of select="author"/> &#8212; <xsl:value-of select="pubDate"/></p>
+            <p><xsl:value-of select="description"/></p>
+          </article>
+        </xsl:for-each>
+      </body>
+    </html>
+  </xsl:template>
+  <xsl:template match="/atom:feed">
+    <html>
+      <head><title><xsl:value-of select="atom:title"/></title></head>
+      <body>
+        <h1><xsl:value-of select="atom:title"/></h1>
+        <xsl:for-each select="atom:entry">
+          <article>
+            <h2><a href="{atom:link/@href}"><xsl:value-of select="atom:title"/></a></h2>
+            <p><xsl:value-of select="atom:author/atom:name"/> &#8212; <xsl:value-of select="atom:updated"/></p>
+            <p><xsl:value-of select="atom:summary"/></p>
+          </article>
+        </xsl:for-each>
+      </body>
+    </html>
+  </xsl:template>
+</xsl:stylesheet>
+`
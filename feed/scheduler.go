@@ -0,0 +1,371 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"personal-kb/services/rss/logging"
+)
+
+const (
+	defaultTickInterval   = 15 * time.Second
+	defaultRefreshSeconds = 300
+	maxFetchJitter        = 500 * time.Millisecond
+
+	// defaultCacheMaxAge and defaultCacheMaxSizeBytes bound the on-disk raw
+	// body cache when SchedulerConfig.CacheDir is set.
+	defaultCacheMaxAge       = 7 * 24 * time.Hour
+	defaultCacheMaxSizeBytes = 50 * 1024 * 1024
+
+	// maxBackoffShift caps how far a feed's interval can be doubled after
+	// consecutive failures, so a permanently broken feed still gets retried
+	// every so often rather than essentially never.
+	maxBackoffShift = 5 // 2^5 = 32x the configured interval
+)
+
+// BackoffInterval returns the effective refresh interval for a feed that has
+// failed consecutiveFailures times in a row: the interval doubles with each
+// failure, up to 2^maxBackoffShift times, so a broken feed is retried less
+// often instead of hammering an upstream that's already failing.
+func BackoffInterval(interval time.Duration, consecutiveFailures int) time.Duration {
+	if consecutiveFailures <= 0 {
+		return interval
+	}
+	shift := consecutiveFailures
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	return interval * time.Duration(int64(1)<<uint(shift))
+}
+
+// SchedulerConfig controls the background refresh cadence.
+type SchedulerConfig struct {
+	FeedsPath          string
+	DefaultIntervalSec int
+	MaxPostsPerFeed    int
+	FetchTimeout       time.Duration
+	// TickInterval controls how often the scheduler checks which feeds are
+	// due for a refresh. It defaults to 15s.
+	TickInterval time.Duration
+	// DBPath, when set, persists fetched posts to a SQLite database at this
+	// path so the in-memory Store can be rehydrated across restarts. Left
+	// empty, the Scheduler runs in-memory only.
+	DBPath string
+	// CacheDir, when set, persists each feed's raw response body on disk, so
+	// a last-known-good body is available if a conditional fetch's network
+	// request fails outright. Left empty, no raw bodies are cached. Hit/miss
+	// metrics are in-memory only and reset on restart regardless.
+	CacheDir string
+	// ContentCachePath, when set, persists full-content-scraped article
+	// bodies on disk so feeds with FullContent enabled don't re-scrape
+	// unchanged articles across restarts. Left empty, scraped content still
+	// works but is never cached between process runs.
+	ContentCachePath string
+}
+
+// Scheduler periodically re-fetches configured feeds in the background,
+// honoring each feed's own RefreshIntervalSec, and keeps a Store up to date
+// so request handlers can serve from memory instead of blocking on upstream
+// fetches.
+type Scheduler struct {
+	cfg     SchedulerConfig
+	store   *Store
+	states  *StateStore
+	db      *SQLStore
+	cache   *BodyCache
+	content *ContentCache
+	loggers *logging.Loggers
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewScheduler builds a Scheduler that refreshes into store, using states to
+// track per-feed caching validators and last-fetch times. loggers may be
+// nil, in which case errors that would otherwise be logged are silently
+// dropped, same as before this field existed.
+func NewScheduler(cfg SchedulerConfig, store *Store, states *StateStore, loggers *logging.Loggers) *Scheduler {
+	return &Scheduler{
+		cfg:     cfg,
+		store:   store,
+		states:  states,
+		loggers: loggers,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Store returns the Scheduler's backing post index.
+func (s *Scheduler) Store() *Store { return s.store }
+
+// States returns the Scheduler's backing caching-state store.
+func (s *Scheduler) States() *StateStore { return s.states }
+
+// ContentCache returns the Scheduler's full-content scraping cache, or nil
+// if no ContentCachePath was configured.
+func (s *Scheduler) ContentCache() *ContentCache { return s.content }
+
+// Start opens the persistent store (if configured), rehydrates the in-memory
+// Store from it, and launches the background refresh loop in its own
+// goroutine, returning immediately. Call Stop for graceful shutdown.
+func (s *Scheduler) Start(ctx context.Context) {
+	if s.cfg.DBPath != "" {
+		if db, err := OpenSQLStore(s.cfg.DBPath); err == nil {
+			s.db = db
+			s.hydrateFromDB(ctx)
+		}
+	}
+	if s.cfg.CacheDir != "" {
+		if cache, err := NewBodyCache(s.cfg.CacheDir, defaultCacheMaxAge, defaultCacheMaxSizeBytes); err == nil {
+			s.cache = cache
+		}
+	}
+	if s.cfg.ContentCachePath != "" {
+		if content, err := NewContentCache(s.cfg.ContentCachePath); err == nil {
+			s.content = content
+		}
+	}
+	go s.run(ctx)
+}
+
+// CacheStats returns the cumulative raw-body cache hit/miss counts, or
+// (0, 0) if no CacheDir was configured.
+func (s *Scheduler) CacheStats() (hits, misses int64) {
+	if s.cache == nil {
+		return 0, 0
+	}
+	return s.cache.Hits(), s.cache.Misses()
+}
+
+// FeedStatus summarizes a configured feed's scheduling and caching state, as
+// reported by Scheduler.Status.
+type FeedStatus struct {
+	Name                string
+	URL                 string
+	ETag                string
+	LastFetched         time.Time
+	LastError           string
+	ConsecutiveFailures int
+	// NextFetch is when the scheduler next expects to refresh this feed,
+	// accounting for any backoff from ConsecutiveFailures. It is the zero
+	// Time if the feed has never been fetched, since it's then due
+	// immediately.
+	NextFetch time.Time
+}
+
+// Status reports the current scheduling and caching state of every
+// configured feed, for the GET /feeds/status admin endpoint.
+func (s *Scheduler) Status() ([]FeedStatus, error) {
+	feeds, err := LoadFeeds(s.cfg.FeedsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultInterval := time.Duration(s.cfg.DefaultIntervalSec) * time.Second
+	if defaultInterval <= 0 {
+		defaultInterval = defaultRefreshSeconds * time.Second
+	}
+
+	statuses := make([]FeedStatus, 0, len(feeds))
+	for _, fd := range feeds {
+		interval := defaultInterval
+		if fd.RefreshIntervalSec > 0 {
+			interval = time.Duration(fd.RefreshIntervalSec) * time.Second
+		}
+
+		st := s.states.Get(fd.URL)
+		var next time.Time
+		if !st.LastFetched.IsZero() {
+			next = st.LastFetched.Add(BackoffInterval(interval, st.ConsecutiveFailures))
+		}
+
+		statuses = append(statuses, FeedStatus{
+			Name:                fd.Name,
+			URL:                 fd.URL,
+			ETag:                st.ETag,
+			LastFetched:         st.LastFetched,
+			LastError:           st.LastError,
+			ConsecutiveFailures: st.ConsecutiveFailures,
+			NextFetch:           next,
+		})
+	}
+	return statuses, nil
+}
+
+// hydrateFromDB loads persisted posts into the in-memory Store so it isn't
+// empty immediately after a restart, before the first scheduled refresh
+// completes.
+func (s *Scheduler) hydrateFromDB(ctx context.Context) {
+	byFeed, err := s.db.LoadAll(ctx)
+	if err != nil || len(byFeed) == 0 {
+		return
+	}
+	feeds, err := LoadFeeds(s.cfg.FeedsPath)
+	if err != nil {
+		return
+	}
+	for _, fd := range feeds {
+		if posts, ok := byFeed[fd.URL]; ok {
+			s.store.UpdatePosts(fd, posts)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	tick := s.cfg.TickInterval
+	if tick <= 0 {
+		tick = defaultTickInterval
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	s.refresh(ctx, false)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.refresh(ctx, false)
+		}
+	}
+}
+
+// Stop signals the background loop to exit, blocks until it has, and closes
+// the persistent store if one was opened.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	<-s.done
+	if s.db != nil {
+		s.db.Close()
+	}
+}
+
+// RefreshNow immediately refreshes every configured feed, ignoring each
+// feed's configured interval. It blocks until all feeds have been attempted.
+// Used by the GET /rss/refresh admin endpoint.
+func (s *Scheduler) RefreshNow(ctx context.Context) {
+	s.refresh(ctx, true)
+}
+
+// ErrFeedNotConfigured is returned by RefreshFeed when feedURL isn't one of
+// the configured feeds.
+var ErrFeedNotConfigured = errors.New("feed not configured")
+
+// RefreshFeed immediately refreshes a single feed, identified by URL,
+// ignoring its configured interval. Used by POST /rss/refresh?url=... to
+// refresh one source without waiting on or disturbing the others.
+func (s *Scheduler) RefreshFeed(ctx context.Context, feedURL string) error {
+	feeds, err := LoadFeeds(s.cfg.FeedsPath)
+	if err != nil {
+		return err
+	}
+	for _, fd := range feeds {
+		if fd.URL != feedURL {
+			continue
+		}
+		interval := time.Duration(s.cfg.DefaultIntervalSec) * time.Second
+		if fd.RefreshIntervalSec > 0 {
+			interval = time.Duration(fd.RefreshIntervalSec) * time.Second
+		}
+		s.refreshOne(ctx, fd, s.states.Get(fd.URL), FetchOptions{ForceRefresh: true}, interval)
+		return nil
+	}
+	return ErrFeedNotConfigured
+}
+
+func (s *Scheduler) refresh(ctx context.Context, force bool) {
+	feeds, err := LoadFeeds(s.cfg.FeedsPath)
+	if err != nil {
+		return
+	}
+
+	defaultInterval := time.Duration(s.cfg.DefaultIntervalSec) * time.Second
+	if defaultInterval <= 0 {
+		defaultInterval = defaultRefreshSeconds * time.Second
+	}
+
+	var wg sync.WaitGroup
+	for _, fd := range feeds {
+		interval := defaultInterval
+		if fd.RefreshIntervalSec > 0 {
+			interval = time.Duration(fd.RefreshIntervalSec) * time.Second
+		}
+
+		prev := s.states.Get(fd.URL)
+		effectiveInterval := BackoffInterval(interval, prev.ConsecutiveFailures)
+		if !force && !prev.LastFetched.IsZero() && time.Since(prev.LastFetched) < effectiveInterval {
+			continue
+		}
+
+		wg.Add(1)
+		go func(fd Feed, prev FeedState, interval time.Duration) {
+			defer wg.Done()
+			s.refreshOne(ctx, fd, prev, FetchOptions{ForceRefresh: force}, interval)
+		}(fd, prev, interval)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) refreshOne(ctx context.Context, fd Feed, prev FeedState, opts FetchOptions, interval time.Duration) {
+	// Stagger fetches so a burst of due feeds doesn't hammer every upstream
+	// at once.
+	select {
+	case <-time.After(time.Duration(rand.Int63n(int64(maxFetchJitter)))):
+	case <-ctx.Done():
+		return
+	}
+
+	timeout := s.cfg.FetchTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tmpl, err := CompileTemplates(fd)
+	if err != nil {
+		state := prev
+		state.LastError = err.Error()
+		state.LastFetched = time.Now().UTC()
+		state.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+		s.states.Update(fd.URL, state)
+		s.store.SetError(fd, err)
+		return
+	}
+
+	posts, state, _, err := FetchFeedConditional(fetchCtx, fd.URL, s.cfg.MaxPostsPerFeed, prev, opts, tmpl, s.cache)
+	if err != nil {
+		state = prev
+		state.LastError = err.Error()
+		state.LastFetched = time.Now().UTC()
+		state.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+		s.states.Update(fd.URL, state)
+		s.store.SetError(fd, err)
+		return
+	}
+	state.ConsecutiveFailures = 0
+
+	EnrichFeedPosts(ctx, fd, posts, s.content, timeout)
+
+	for i := range posts {
+		posts[i].FeedName = fd.Name
+		posts[i].Categories = fd.Categories
+		posts[i].Tags = fd.Tags
+	}
+	state.Posts = posts
+	s.states.Update(fd.URL, state)
+	s.store.UpdatePosts(fd, posts)
+
+	if s.db != nil {
+		if err := s.db.UpsertPosts(fd.URL, posts, time.Now().Add(interval)); err != nil && s.loggers != nil {
+			s.loggers.Error.Error("failed to persist posts to database", "feed", fd.URL, "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScheduler_RefreshNowPopulatesStore(t *testing.T) {
+	srv := newFeedServer("Scheduled", "2026-02-16T10:00:00Z")
+	defer srv.Close()
+
+	dir := t.TempDir()
+	feedsPath := filepath.Join(dir, "feeds.yaml")
+	if err := SaveFeeds(feedsPath, []Feed{{Name: "Scheduled", URL: srv.URL}}); err != nil {
+		t.Fatalf("SaveFeeds: %v", err)
+	}
+
+	states, err := NewStateStore(filepath.Join(dir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore: %v", err)
+	}
+	store := NewStore()
+	sched := NewScheduler(SchedulerConfig{
+		FeedsPath:          feedsPath,
+		DefaultIntervalSec: 300,
+		MaxPostsPerFeed:    10,
+		FetchTimeout:       5 * time.Second,
+	}, store, states, nil)
+
+	sched.RefreshNow(context.Background())
+
+	posts := store.ByFeed("Scheduled")
+	if len(posts) != 1 {
+		t.Fatalf("expected 1 post after RefreshNow, got %d", len(posts))
+	}
+}
+
+func TestScheduler_SkipsFeedNotYetDue(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `<rss version="2.0"><channel><title>F</title></channel></rss>`)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	feedsPath := filepath.Join(dir, "feeds.yaml")
+	if err := SaveFeeds(feedsPath, []Feed{{Name: "F", URL: srv.URL, RefreshIntervalSec: 3600}}); err != nil {
+		t.Fatalf("SaveFeeds: %v", err)
+	}
+
+	states, err := NewStateStore(filepath.Join(dir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore: %v", err)
+	}
+	if err := states.Update(srv.URL, FeedState{LastFetched: time.Now().UTC()}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	store := NewStore()
+	sched := NewScheduler(SchedulerConfig{
+		FeedsPath:          feedsPath,
+		DefaultIntervalSec: 300,
+		MaxPostsPerFeed:    10,
+		FetchTimeout:       5 * time.Second,
+	}, store, states, nil)
+
+	// A just-fetched feed with an hour-long interval shouldn't be re-fetched
+	// by a non-forced refresh.
+	sched.refresh(context.Background(), false)
+
+	if calls != 0 {
+		t.Errorf("expected feed not due for refresh to be skipped, got %d calls", calls)
+	}
+}
+
+func TestBackoffInterval_DoublesPerFailureUpToCap(t *testing.T) {
+	base := 10 * time.Minute
+	cases := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, base},
+		{1, 20 * time.Minute},
+		{2, 40 * time.Minute},
+		{5, base * 32},
+		{9, base * 32}, // capped at maxBackoffShift
+	}
+	for _, c := range cases {
+		if got := BackoffInterval(base, c.failures); got != c.want {
+			t.Errorf("BackoffInterval(%v, %d) = %v, want %v", base, c.failures, got, c.want)
+		}
+	}
+}
+
+func TestScheduler_StatusReflectsBackoffAfterFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	feedsPath := filepath.Join(dir, "feeds.yaml")
+	if err := SaveFeeds(feedsPath, []Feed{{Name: "Flaky", URL: srv.URL, RefreshIntervalSec: 60}}); err != nil {
+		t.Fatalf("SaveFeeds: %v", err)
+	}
+
+	states, err := NewStateStore(filepath.Join(dir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore: %v", err)
+	}
+	sched := NewScheduler(SchedulerConfig{
+		FeedsPath:          feedsPath,
+		DefaultIntervalSec: 300,
+		MaxPostsPerFeed:    10,
+		FetchTimeout:       5 * time.Second,
+	}, NewStore(), states, nil)
+
+	sched.RefreshNow(context.Background())
+	sched.RefreshNow(context.Background())
+
+	statuses, err := sched.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 status, got %d", len(statuses))
+	}
+	st := statuses[0]
+	if st.ConsecutiveFailures != 2 {
+		t.Errorf("ConsecutiveFailures = %d, want 2", st.ConsecutiveFailures)
+	}
+	wantNext := st.LastFetched.Add(BackoffInterval(60*time.Second, 2))
+	if !st.NextFetch.Equal(wantNext) {
+		t.Errorf("NextFetch = %v, want %v", st.NextFetch, wantNext)
+	}
+}
+
+func TestScheduler_CacheStatsTracksHitsAndMisses(t *testing.T) {
+	srv := newFeedServer("Cached", "2026-02-16T10:00:00Z")
+	defer srv.Close()
+
+	dir := t.TempDir()
+	feedsPath := filepath.Join(dir, "feeds.yaml")
+	if err := SaveFeeds(feedsPath, []Feed{{Name: "Cached", URL: srv.URL}}); err != nil {
+		t.Fatalf("SaveFeeds: %v", err)
+	}
+
+	states, err := NewStateStore(filepath.Join(dir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore: %v", err)
+	}
+	sched := NewScheduler(SchedulerConfig{
+		FeedsPath:          feedsPath,
+		DefaultIntervalSec: 300,
+		MaxPostsPerFeed:    10,
+		FetchTimeout:       5 * time.Second,
+		CacheDir:           filepath.Join(dir, "cache"),
+	}, NewStore(), states, nil)
+
+	if hits, misses := sched.CacheStats(); hits != 0 || misses != 0 {
+		t.Fatalf("expected zero stats before Start, got hits=%d misses=%d", hits, misses)
+	}
+
+	sched.Start(context.Background())
+	defer sched.Stop()
+
+	sched.RefreshNow(context.Background())
+	sched.RefreshNow(context.Background())
+
+	hits, misses := sched.CacheStats()
+	if misses == 0 {
+		t.Errorf("expected at least one cache miss after first refresh, got 0")
+	}
+	if hits == 0 {
+		t.Errorf("expected at least one cache hit once the unchanged body's content hash matches, got 0")
+	}
+}
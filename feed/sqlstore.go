@@ -0,0 +1,151 @@
+package feed
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// SQLStore persists posts to a SQLite database, so the in-memory Store can be
+// rehydrated across restarts instead of starting empty until the next
+// scheduled refresh. It's a write-through companion to Store, not a
+// replacement for it: request handlers keep reading from the in-memory
+// Store, and the Scheduler writes through to both on every successful fetch.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// OpenSQLStore opens (creating if needed) the SQLite database at path and
+// applies any pending schema migrations. WAL mode and a busy timeout are set
+// so the concurrent per-feed writes the scheduler issues during a refresh
+// don't immediately fail with SQLITE_BUSY against each other.
+func OpenSQLStore(path string) (*SQLStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`PRAGMA journal_mode = WAL`); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(`PRAGMA busy_timeout = 5000`); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLStore{db: db}, nil
+}
+
+// migrate applies every embedded migrations/NNNN_*.sql file that hasn't yet
+// been recorded in schema_migrations, in numeric order.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	entries, err := migrationFS.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, e := range entries {
+		var version int
+		if _, err := fmt.Sscanf(e.Name(), "%d_", &version); err != nil {
+			return fmt.Errorf("migration file %q has no numeric prefix: %w", e.Name(), err)
+		}
+
+		var applied int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, version).Scan(&applied); err != nil {
+			return err
+		}
+		if applied > 0 {
+			continue
+		}
+
+		contents, err := migrationFS.ReadFile("migrations/" + e.Name())
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(string(contents)); err != nil {
+			return fmt.Errorf("migration %s: %w", e.Name(), err)
+		}
+		if _, err := db.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertPosts persists posts for feedURL, replacing any existing rows that
+// share the same (feed_url, url), and records when the feed is next due for
+// refresh.
+func (s *SQLStore) UpsertPosts(feedURL string, posts []Post, nextRefresh time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range posts {
+		if _, err := tx.Exec(`
+			INSERT INTO posts (feed_url, url, feed_name, title, published_at, summary, content, author)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(feed_url, url) DO UPDATE SET
+				feed_name    = excluded.feed_name,
+				title        = excluded.title,
+				published_at = excluded.published_at,
+				summary      = excluded.summary,
+				content      = excluded.content,
+				author       = excluded.author
+		`, feedURL, p.URL, p.FeedName, p.Title, p.PublishedAt.UTC(), p.Summary, p.Content, p.Author); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO feed_refresh (feed_url, next_refresh) VALUES (?, ?)
+		ON CONFLICT(feed_url) DO UPDATE SET next_refresh = excluded.next_refresh
+	`, feedURL, nextRefresh.UTC()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// LoadAll returns every persisted post, grouped by feed_url, for rehydrating
+// the in-memory Store at startup.
+func (s *SQLStore) LoadAll(ctx context.Context) (map[string][]Post, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT feed_url, feed_name, title, url, published_at, summary, content, author FROM posts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byFeed := map[string][]Post{}
+	for rows.Next() {
+		var feedURL string
+		var p Post
+		if err := rows.Scan(&feedURL, &p.FeedName, &p.Title, &p.URL, &p.PublishedAt, &p.Summary, &p.Content, &p.Author); err != nil {
+			return nil, err
+		}
+		byFeed[feedURL] = append(byFeed[feedURL], p)
+	}
+	return byFeed, rows.Err()
+}
+
+// Close closes the underlying database handle.
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,110 @@
+package feed
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLStore_UpsertAndLoadAll(t *testing.T) {
+	db, err := OpenSQLStore(filepath.Join(t.TempDir(), "posts.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLStore: %v", err)
+	}
+	defer db.Close()
+
+	posts := []Post{
+		{FeedName: "Hacker News", Title: "Post One", URL: "https://example.com/1", PublishedAt: time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC)},
+		{FeedName: "Hacker News", Title: "Post Two", URL: "https://example.com/2", PublishedAt: time.Date(2026, 2, 15, 9, 0, 0, 0, time.UTC)},
+	}
+	if err := db.UpsertPosts("https://news.ycombinator.com/rss", posts, time.Now().Add(15*time.Minute)); err != nil {
+		t.Fatalf("UpsertPosts: %v", err)
+	}
+
+	byFeed, err := db.LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	got := byFeed["https://news.ycombinator.com/rss"]
+	if len(got) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(got))
+	}
+}
+
+func TestSQLStore_UpsertDedupsOnRefetch(t *testing.T) {
+	db, err := OpenSQLStore(filepath.Join(t.TempDir(), "posts.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLStore: %v", err)
+	}
+	defer db.Close()
+
+	feedURL := "https://example.com/rss"
+	first := []Post{{FeedName: "F", Title: "Old Title", URL: "https://example.com/1"}}
+	if err := db.UpsertPosts(feedURL, first, time.Now()); err != nil {
+		t.Fatalf("UpsertPosts: %v", err)
+	}
+
+	// Re-fetching the same post (same feed_url+url) with an updated title
+	// should update the row in place rather than duplicate it.
+	second := []Post{{FeedName: "F", Title: "New Title", URL: "https://example.com/1"}}
+	if err := db.UpsertPosts(feedURL, second, time.Now()); err != nil {
+		t.Fatalf("UpsertPosts: %v", err)
+	}
+
+	byFeed, err := db.LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	got := byFeed[feedURL]
+	if len(got) != 1 {
+		t.Fatalf("expected dedup to leave 1 post, got %d", len(got))
+	}
+	if got[0].Title != "New Title" {
+		t.Errorf("expected row to be updated in place, got title %q", got[0].Title)
+	}
+}
+
+func TestScheduler_RehydratesStoreFromDB(t *testing.T) {
+	dir := t.TempDir()
+	feedsPath := filepath.Join(dir, "feeds.yaml")
+	if err := SaveFeeds(feedsPath, []Feed{{Name: "Hydrated", URL: "https://example.com/rss"}}); err != nil {
+		t.Fatalf("SaveFeeds: %v", err)
+	}
+
+	dbPath := filepath.Join(dir, "posts.db")
+	db, err := OpenSQLStore(dbPath)
+	if err != nil {
+		t.Fatalf("OpenSQLStore: %v", err)
+	}
+	seeded := []Post{{FeedName: "Hydrated", Title: "Seeded Post", URL: "https://example.com/1"}}
+	if err := db.UpsertPosts("https://example.com/rss", seeded, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("UpsertPosts: %v", err)
+	}
+	db.Close()
+
+	states, err := NewStateStore(filepath.Join(dir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore: %v", err)
+	}
+	sched := NewScheduler(SchedulerConfig{
+		FeedsPath:          feedsPath,
+		DefaultIntervalSec: 3600,
+		MaxPostsPerFeed:    10,
+		FetchTimeout:       5 * time.Second,
+		DBPath:             dbPath,
+	}, NewStore(), states, nil)
+
+	reopened, err := OpenSQLStore(dbPath)
+	if err != nil {
+		t.Fatalf("OpenSQLStore: %v", err)
+	}
+	defer reopened.Close()
+	sched.db = reopened
+	sched.hydrateFromDB(context.Background())
+
+	posts := sched.Store().ByFeed("Hydrated")
+	if len(posts) != 1 || posts[0].Title != "Seeded Post" {
+		t.Fatalf("expected store to be rehydrated from the persistent DB, got %+v", posts)
+	}
+}
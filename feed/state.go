@@ -0,0 +1,118 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FeedState tracks the HTTP caching validators and last known results for a
+// single feed, so subsequent fetches can send conditional GETs instead of
+// re-downloading and re-parsing unchanged content.
+type FeedState struct {
+	ETag         string    `yaml:"etag,omitempty"`
+	LastModified string    `yaml:"last_modified,omitempty"`
+	ContentHash  string    `yaml:"content_hash,omitempty"`
+	LastFetched  time.Time `yaml:"last_fetched,omitempty"`
+	LastError    string    `yaml:"last_error,omitempty"`
+	Posts        []Post    `yaml:"posts,omitempty"`
+	// FreshUntil, when set, is the time before which the feed is known fresh
+	// per its Cache-Control max-age or Expires header, so a fetch can be
+	// skipped entirely rather than sent conditionally.
+	FreshUntil time.Time `yaml:"fresh_until,omitempty"`
+	// ConsecutiveFailures counts fetches that have failed in a row since the
+	// last success, resetting to zero on the next successful fetch. The
+	// scheduler uses it to back off a misbehaving feed instead of retrying it
+	// on its normal cadence.
+	ConsecutiveFailures int `yaml:"consecutive_failures,omitempty"`
+}
+
+type stateFile struct {
+	Feeds map[string]FeedState `yaml:"feeds"`
+}
+
+// StateStore persists per-feed caching state to a sidecar YAML file next to
+// feeds.yaml, so ETag/Last-Modified validators and parsed posts survive
+// restarts.
+type StateStore struct {
+	// mu guards both the in-memory map and the on-disk file it's mirrored
+	// to: Update holds it across the whole mutate-snapshot-write sequence,
+	// not just the map mutation, so two feeds refreshed concurrently can't
+	// interleave their writes and have the later-snapshotting goroutine's
+	// os.WriteFile silently discard the other's just-recorded state.
+	mu    sync.Mutex
+	path  string
+	feeds map[string]FeedState
+}
+
+// NewStateStore loads state from path, creating an empty store if the file
+// does not yet exist.
+func NewStateStore(path string) (*StateStore, error) {
+	feeds, err := loadState(path)
+	if err != nil {
+		return nil, err
+	}
+	return &StateStore{path: path, feeds: feeds}, nil
+}
+
+func loadState(path string) (map[string]FeedState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]FeedState{}, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return map[string]FeedState{}, nil
+	}
+
+	var sf stateFile
+	if err := yaml.Unmarshal(data, &sf); err != nil {
+		return nil, err
+	}
+	if sf.Feeds == nil {
+		sf.Feeds = map[string]FeedState{}
+	}
+	return sf.Feeds, nil
+}
+
+// Get returns the cached state for feedURL, or the zero value if unknown.
+func (s *StateStore) Get(feedURL string) FeedState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.feeds[feedURL]
+}
+
+// Update records new state for feedURL and persists the whole store to disk.
+func (s *StateStore) Update(feedURL string, state FeedState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feeds[feedURL] = state
+	return saveState(s.path, s.feeds)
+}
+
+// All returns a copy of every feed's cached state, keyed by feed URL.
+func (s *StateStore) All() map[string]FeedState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]FeedState, len(s.feeds))
+	for k, v := range s.feeds {
+		out[k] = v
+	}
+	return out
+}
+
+func saveState(path string, feeds map[string]FeedState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(&stateFile{Feeds: feeds})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
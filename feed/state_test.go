@@ -0,0 +1,118 @@
+package feed
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewStateStore_MissingFile(t *testing.T) {
+	store, err := NewStateStore("/nonexistent/state.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.All()) != 0 {
+		t.Errorf("expected empty store, got %d entries", len(store.All()))
+	}
+}
+
+func TestStateStore_UpdateAndGet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.yaml")
+
+	store, err := NewStateStore(path)
+	if err != nil {
+		t.Fatalf("NewStateStore failed: %v", err)
+	}
+
+	state := FeedState{
+		ETag:        `"abc123"`,
+		ContentHash: "deadbeef",
+		LastFetched: time.Now().UTC(),
+		Posts:       []Post{{Title: "Hello"}},
+	}
+	if err := store.Update("https://example.com/rss", state); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got := store.Get("https://example.com/rss")
+	if got.ETag != state.ETag {
+		t.Errorf("ETag = %q, want %q", got.ETag, state.ETag)
+	}
+	if len(got.Posts) != 1 {
+		t.Errorf("expected 1 cached post, got %d", len(got.Posts))
+	}
+}
+
+func TestStateStore_PersistsAcrossReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.yaml")
+
+	store, err := NewStateStore(path)
+	if err != nil {
+		t.Fatalf("NewStateStore failed: %v", err)
+	}
+	if err := store.Update("https://example.com/rss", FeedState{ETag: `"v1"`}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	reloaded, err := NewStateStore(path)
+	if err != nil {
+		t.Fatalf("reload NewStateStore failed: %v", err)
+	}
+	got := reloaded.Get("https://example.com/rss")
+	if got.ETag != `"v1"` {
+		t.Errorf("ETag after reload = %q, want %q", got.ETag, `"v1"`)
+	}
+}
+
+func TestStateStore_ConcurrentUpdatesToDifferentFeedsAllPersist(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.yaml")
+
+	store, err := NewStateStore(path)
+	if err != nil {
+		t.Fatalf("NewStateStore failed: %v", err)
+	}
+
+	const feedCount = 8
+	var wg sync.WaitGroup
+	for i := 0; i < feedCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("https://example.com/feed%d", i)
+			if err := store.Update(url, FeedState{ETag: fmt.Sprintf("v%d", i)}); err != nil {
+				t.Errorf("Update failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	reloaded, err := NewStateStore(path)
+	if err != nil {
+		t.Fatalf("reload NewStateStore failed: %v", err)
+	}
+	for i := 0; i < feedCount; i++ {
+		url := fmt.Sprintf("https://example.com/feed%d", i)
+		want := fmt.Sprintf("v%d", i)
+		if got := reloaded.Get(url).ETag; got != want {
+			t.Errorf("feed %d: ETag = %q, want %q (concurrent update lost)", i, got, want)
+		}
+	}
+}
+
+func TestStateStore_GetUnknownFeed(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStateStore(filepath.Join(dir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore failed: %v", err)
+	}
+
+	got := store.Get("https://unknown.example.com/rss")
+	if got.ETag != "" || !got.LastFetched.IsZero() {
+		t.Errorf("expected zero-value state for unknown feed, got %+v", got)
+	}
+}
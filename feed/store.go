@@ -4,15 +4,71 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 )
 
 var ErrDuplicateFeed = errors.New("feed URL already exists")
+var ErrFeedNotFound = errors.New("feed not found")
+var ErrCategoryNotFound = errors.New("category not found")
 
 type Feed struct {
-	Name string `yaml:"name" json:"name"`
-	URL  string `yaml:"url" json:"url"`
+	Name       string   `yaml:"name" json:"name"`
+	URL        string   `yaml:"url" json:"url"`
+	Categories []string `yaml:"categories,omitempty" json:"categories,omitempty"`
+	// Tags are free-form labels, distinct from Categories: a feed's
+	// categories group it for navigation (GET /categories), while tags are
+	// ad-hoc and filtered on directly via GET /rss?tags=a,b. Older
+	// feeds.yaml entries without a tags field load with Tags == nil.
+	Tags               []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	RefreshIntervalSec int      `yaml:"refresh_interval_sec,omitempty" json:"refresh_interval_sec,omitempty"`
+	// TitleTemplate and URLTemplate are optional Go text/template strings
+	// applied to each Post fetched from this feed, with ".Item" bound to
+	// the raw *gofeed.Item. See CompileTemplates.
+	TitleTemplate string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	URLTemplate   string `yaml:"url_template,omitempty" json:"url_template,omitempty"`
+	// FullContent opts this feed into fetch-time content enrichment: the
+	// scheduler scrapes each post's article URL and replaces its Content
+	// with the extracted main body, rather than leaving that to an on-demand
+	// GET /rss?fulltext=1 request. See EnrichFeedPosts.
+	FullContent bool `yaml:"full_content,omitempty" json:"full_content,omitempty"`
+	// ScraperRules is a CSS selector identifying the article's main content
+	// element (e.g. "article" or ".post-body"); only consulted when
+	// FullContent is set. Left empty, FetchArticleContent falls back to the
+	// generic distillText heuristic over the whole page.
+	ScraperRules string `yaml:"scraper_rules,omitempty" json:"scraper_rules,omitempty"`
+	// RewriteRules are regex replace pairs applied, in order, to every
+	// post's Title and Content after fetching (and after any full-content
+	// scraping). Useful for stripping boilerplate a feed or site always
+	// includes.
+	RewriteRules []RewriteRule `yaml:"rewrite_rules,omitempty" json:"rewrite_rules,omitempty"`
+}
+
+// RewriteRule is a single regex replace pair, applied by ApplyRewriteRules.
+type RewriteRule struct {
+	Pattern string `yaml:"pattern" json:"pattern"`
+	Replace string `yaml:"replace" json:"replace"`
+}
+
+// HasCategory reports whether f is tagged with the given category.
+func (f Feed) HasCategory(category string) bool {
+	for _, c := range f.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether f carries the given free-form tag.
+func (f Feed) HasTag(tag string) bool {
+	for _, t := range f.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
 
 type feedsFile struct {
@@ -70,6 +126,117 @@ func AddFeed(path string, feed Feed) error {
 	return SaveFeeds(path, existing)
 }
 
+// RemoveFeed deletes the feed identified by its URL, returning
+// ErrFeedNotFound if no feed has that URL.
+func RemoveFeed(path, url string) error {
+	existing, err := LoadFeeds(path)
+	if err != nil {
+		return err
+	}
+	for i, f := range existing {
+		if f.URL == url {
+			existing = append(existing[:i], existing[i+1:]...)
+			return SaveFeeds(path, existing)
+		}
+	}
+	return ErrFeedNotFound
+}
+
+// UpdateFeed changes the name and/or URL of the feed identified by its
+// current URL, returning ErrFeedNotFound if no feed has that URL, or
+// ErrDuplicateFeed if newURL already belongs to a different configured feed.
+// An empty newName or newURL leaves that field unchanged.
+func UpdateFeed(path, url, newName, newURL string) (Feed, error) {
+	existing, err := LoadFeeds(path)
+	if err != nil {
+		return Feed{}, err
+	}
+	for i := range existing {
+		if existing[i].URL != url {
+			continue
+		}
+		if newURL != "" && newURL != existing[i].URL {
+			for j, f := range existing {
+				if j != i && f.URL == newURL {
+					return Feed{}, ErrDuplicateFeed
+				}
+			}
+			existing[i].URL = newURL
+		}
+		if newName != "" {
+			existing[i].Name = newName
+		}
+		if err := SaveFeeds(path, existing); err != nil {
+			return Feed{}, err
+		}
+		return existing[i], nil
+	}
+	return Feed{}, ErrFeedNotFound
+}
+
+// SetFeedCategories replaces the categories assigned to the feed identified
+// by its URL, returning ErrFeedNotFound if no feed has that URL.
+func SetFeedCategories(path, url string, categories []string) (Feed, error) {
+	existing, err := LoadFeeds(path)
+	if err != nil {
+		return Feed{}, err
+	}
+	for i := range existing {
+		if existing[i].URL == url {
+			existing[i].Categories = categories
+			if err := SaveFeeds(path, existing); err != nil {
+				return Feed{}, err
+			}
+			return existing[i], nil
+		}
+	}
+	return Feed{}, ErrFeedNotFound
+}
+
+// ListCategories returns every distinct category assigned to any feed,
+// sorted alphabetically.
+func ListCategories(path string) ([]string, error) {
+	existing, err := LoadFeeds(path)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var categories []string
+	for _, f := range existing {
+		for _, c := range f.Categories {
+			if !seen[c] {
+				seen[c] = true
+				categories = append(categories, c)
+			}
+		}
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
+
+// RenameCategory renames oldName to newName on every feed that carries it,
+// returning ErrCategoryNotFound if no feed does.
+func RenameCategory(path, oldName, newName string) error {
+	existing, err := LoadFeeds(path)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i := range existing {
+		for j, c := range existing[i].Categories {
+			if c == oldName {
+				existing[i].Categories[j] = newName
+				found = true
+			}
+		}
+	}
+	if !found {
+		return ErrCategoryNotFound
+	}
+	return SaveFeeds(path, existing)
+}
+
 func EnsureDataDir(dataDir string) error {
 	return os.MkdirAll(dataDir, 0755)
 }
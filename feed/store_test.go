@@ -181,3 +181,242 @@ func TestInitFeedsFile_ExistingFileUntouched(t *testing.T) {
 		t.Errorf("feeds[0].Name = %q, want %q", feeds[0].Name, "Custom")
 	}
 }
+
+func TestHasCategory(t *testing.T) {
+	f := Feed{Name: "Test", URL: "https://example.com", Categories: []string{"tech", "go"}}
+	if !f.HasCategory("go") {
+		t.Error("expected HasCategory(\"go\") to be true")
+	}
+	if f.HasCategory("news") {
+		t.Error("expected HasCategory(\"news\") to be false")
+	}
+}
+
+func TestSetFeedCategories_UpdatesExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Existing", URL: "https://existing.com"}})
+
+	updated, err := SetFeedCategories(path, "https://existing.com", []string{"tech"})
+	if err != nil {
+		t.Fatalf("SetFeedCategories failed: %v", err)
+	}
+	if len(updated.Categories) != 1 || updated.Categories[0] != "tech" {
+		t.Errorf("unexpected categories: %v", updated.Categories)
+	}
+
+	feeds, _ := LoadFeeds(path)
+	if !feeds[0].HasCategory("tech") {
+		t.Error("expected persisted feed to have category \"tech\"")
+	}
+}
+
+func TestSetFeedCategories_NotFound(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Existing", URL: "https://existing.com"}})
+
+	_, err := SetFeedCategories(path, "https://missing.com", []string{"tech"})
+	if !errors.Is(err, ErrFeedNotFound) {
+		t.Errorf("expected ErrFeedNotFound, got: %v", err)
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	f := Feed{Name: "Test", URL: "https://example.com", Tags: []string{"daily", "long-form"}}
+	if !f.HasTag("daily") {
+		t.Error("expected HasTag(\"daily\") to be true")
+	}
+	if f.HasTag("weekly") {
+		t.Error("expected HasTag(\"weekly\") to be false")
+	}
+}
+
+func TestLoadFeeds_LegacyEntryWithoutTagsLoadsFine(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	content := []byte(`feeds:
+  - name: Legacy Feed
+    url: https://legacy.com/rss
+    categories: [tech]
+`)
+	os.WriteFile(path, content, 0644)
+
+	feeds, err := LoadFeeds(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(feeds) != 1 {
+		t.Fatalf("expected 1 feed, got %d", len(feeds))
+	}
+	if feeds[0].Tags != nil {
+		t.Errorf("expected nil Tags on a legacy entry, got %v", feeds[0].Tags)
+	}
+	if !feeds[0].HasCategory("tech") {
+		t.Error("expected legacy category to still load")
+	}
+}
+
+func TestListCategories_DedupesAndSorts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{
+		{Name: "A", URL: "https://a.com", Categories: []string{"tech", "go"}},
+		{Name: "B", URL: "https://b.com", Categories: []string{"news", "tech"}},
+	})
+
+	categories, err := ListCategories(path)
+	if err != nil {
+		t.Fatalf("ListCategories failed: %v", err)
+	}
+	want := []string{"go", "news", "tech"}
+	if len(categories) != len(want) {
+		t.Fatalf("categories = %v, want %v", categories, want)
+	}
+	for i := range want {
+		if categories[i] != want[i] {
+			t.Errorf("categories = %v, want %v", categories, want)
+			break
+		}
+	}
+}
+
+func TestRenameCategory_UpdatesAllFeeds(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{
+		{Name: "A", URL: "https://a.com", Categories: []string{"tech"}},
+		{Name: "B", URL: "https://b.com", Categories: []string{"tech", "go"}},
+	})
+
+	if err := RenameCategory(path, "tech", "technology"); err != nil {
+		t.Fatalf("RenameCategory failed: %v", err)
+	}
+
+	feeds, _ := LoadFeeds(path)
+	for _, f := range feeds {
+		if f.HasCategory("tech") {
+			t.Errorf("feed %q still has the old category name", f.Name)
+		}
+		if !f.HasCategory("technology") {
+			t.Errorf("feed %q missing the renamed category", f.Name)
+		}
+	}
+}
+
+func TestRenameCategory_NotFound(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "A", URL: "https://a.com", Categories: []string{"tech"}}})
+
+	err := RenameCategory(path, "missing", "whatever")
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got: %v", err)
+	}
+}
+
+func TestRemoveFeed_DeletesExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{
+		{Name: "Keep", URL: "https://keep.com"},
+		{Name: "Drop", URL: "https://drop.com"},
+	})
+
+	if err := RemoveFeed(path, "https://drop.com"); err != nil {
+		t.Fatalf("RemoveFeed failed: %v", err)
+	}
+
+	feeds, _ := LoadFeeds(path)
+	if len(feeds) != 1 || feeds[0].URL != "https://keep.com" {
+		t.Errorf("unexpected feeds after removal: %+v", feeds)
+	}
+}
+
+func TestRemoveFeed_NotFound(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Existing", URL: "https://existing.com"}})
+
+	err := RemoveFeed(path, "https://missing.com")
+	if !errors.Is(err, ErrFeedNotFound) {
+		t.Errorf("expected ErrFeedNotFound, got: %v", err)
+	}
+}
+
+func TestUpdateFeed_RenamesAndChangesURL(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Old Name", URL: "https://old.com"}})
+
+	updated, err := UpdateFeed(path, "https://old.com", "New Name", "https://new.com")
+	if err != nil {
+		t.Fatalf("UpdateFeed failed: %v", err)
+	}
+	if updated.Name != "New Name" || updated.URL != "https://new.com" {
+		t.Errorf("unexpected updated feed: %+v", updated)
+	}
+
+	feeds, _ := LoadFeeds(path)
+	if len(feeds) != 1 || feeds[0].Name != "New Name" || feeds[0].URL != "https://new.com" {
+		t.Errorf("unexpected persisted feeds: %+v", feeds)
+	}
+}
+
+func TestUpdateFeed_PartialUpdateLeavesOtherFieldUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Original", URL: "https://original.com"}})
+
+	updated, err := UpdateFeed(path, "https://original.com", "Renamed", "")
+	if err != nil {
+		t.Fatalf("UpdateFeed failed: %v", err)
+	}
+	if updated.Name != "Renamed" || updated.URL != "https://original.com" {
+		t.Errorf("unexpected updated feed: %+v", updated)
+	}
+}
+
+func TestUpdateFeed_NotFound(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Existing", URL: "https://existing.com"}})
+
+	_, err := UpdateFeed(path, "https://missing.com", "New Name", "")
+	if !errors.Is(err, ErrFeedNotFound) {
+		t.Errorf("expected ErrFeedNotFound, got: %v", err)
+	}
+}
+
+func TestUpdateFeed_DuplicateURLRejected(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{
+		{Name: "A", URL: "https://a.com"},
+		{Name: "B", URL: "https://b.com"},
+	})
+
+	_, err := UpdateFeed(path, "https://a.com", "", "https://b.com")
+	if !errors.Is(err, ErrDuplicateFeed) {
+		t.Errorf("expected ErrDuplicateFeed, got: %v", err)
+	}
+
+	feeds, _ := LoadFeeds(path)
+	if feeds[0].URL != "https://a.com" {
+		t.Errorf("expected rejected rename to leave the feed's URL unchanged, got %q", feeds[0].URL)
+	}
+}
+
+func TestUpdateFeed_RenameToOwnURLIsNotADuplicate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.yaml")
+	SaveFeeds(path, []Feed{{Name: "Old Name", URL: "https://same.com"}})
+
+	updated, err := UpdateFeed(path, "https://same.com", "New Name", "https://same.com")
+	if err != nil {
+		t.Fatalf("UpdateFeed failed: %v", err)
+	}
+	if updated.Name != "New Name" || updated.URL != "https://same.com" {
+		t.Errorf("unexpected updated feed: %+v", updated)
+	}
+}
@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// templateData is the value bound to a title/url template's "." — Item is
+// the raw, unmodified gofeed item so templates can reach fields (like
+// Description or Categories) that a Post doesn't carry.
+type templateData struct {
+	Item *gofeed.Item
+}
+
+var templateFuncs = template.FuncMap{
+	"stripPrefix": func(prefix, s string) string {
+		return strings.TrimPrefix(s, prefix)
+	},
+	"trimQuery": func(rawURL string) string {
+		if i := strings.IndexByte(rawURL, '?'); i != -1 {
+			return rawURL[:i]
+		}
+		return rawURL
+	},
+	"regexReplace": func(pattern, repl, s string) string {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return s
+		}
+		return re.ReplaceAllString(s, repl)
+	},
+}
+
+// Templates holds the compiled per-feed title/url transform templates
+// configured via Feed.TitleTemplate/Feed.URLTemplate. Either may be nil if
+// the feed didn't set the corresponding field.
+type Templates struct {
+	Title *template.Template
+	URL   *template.Template
+}
+
+// CompileTemplates compiles f's TitleTemplate and URLTemplate, returning nil
+// if neither is set. It's used both to validate a feed's templates up front
+// (PUT /rss) and to build the Templates applied to each fetched post.
+func CompileTemplates(f Feed) (*Templates, error) {
+	if f.TitleTemplate == "" && f.URLTemplate == "" {
+		return nil, nil
+	}
+
+	var t Templates
+	if f.TitleTemplate != "" {
+		tmpl, err := template.New("title_template").Funcs(templateFuncs).Parse(f.TitleTemplate)
+		if err != nil {
+			return nil, err
+		}
+		t.Title = tmpl
+	}
+	if f.URLTemplate != "" {
+		tmpl, err := template.New("url_template").Funcs(templateFuncs).Parse(f.URLTemplate)
+		if err != nil {
+			return nil, err
+		}
+		t.URL = tmpl
+	}
+	return &t, nil
+}
+
+// Apply rewrites post.Title/post.URL using t's compiled templates, with
+// item bound as the raw source data. A template that fails to execute
+// leaves the corresponding field untouched rather than failing the post.
+func (t *Templates) Apply(item *gofeed.Item, post Post) Post {
+	data := templateData{Item: item}
+	if t.Title != nil {
+		var buf bytes.Buffer
+		if err := t.Title.Execute(&buf, data); err == nil {
+			post.Title = buf.String()
+		}
+	}
+	if t.URL != nil {
+		var buf bytes.Buffer
+		if err := t.URL.Execute(&buf, data); err == nil {
+			post.URL = buf.String()
+		}
+	}
+	return post
+}
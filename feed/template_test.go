@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestCompileTemplates_NilWhenUnset(t *testing.T) {
+	tmpl, err := CompileTemplates(Feed{Name: "Plain", URL: "https://example.com/rss"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl != nil {
+		t.Error("expected nil Templates for a feed with no title_template/url_template")
+	}
+}
+
+func TestCompileTemplates_RejectsBadSyntax(t *testing.T) {
+	_, err := CompileTemplates(Feed{Name: "Bad", URL: "https://example.com/rss", TitleTemplate: "{{ .Item.Title"})
+	if err == nil {
+		t.Fatal("expected error for unparseable title_template")
+	}
+}
+
+func TestFetchFeed_AppliesTitleAndURLTemplates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, sampleRSS)
+	}))
+	defer srv.Close()
+
+	tmpl, err := CompileTemplates(Feed{
+		Name:          "Test Feed",
+		URL:           srv.URL,
+		TitleTemplate: `{{ stripPrefix "Post " .Item.Title }}`,
+		URLTemplate:   `{{ regexReplace "example\\.com" "example.org" .Item.Link }}`,
+	})
+	if err != nil {
+		t.Fatalf("CompileTemplates: %v", err)
+	}
+
+	posts, err := FetchFeed(context.Background(), "", srv.URL, 1, tmpl)
+	if err != nil {
+		t.Fatalf("FetchFeed: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(posts))
+	}
+	if posts[0].Title != "One" {
+		t.Errorf("Title = %q, want %q", posts[0].Title, "One")
+	}
+	if posts[0].URL != "https://example.org/1" {
+		t.Errorf("URL = %q, want %q", posts[0].URL, "https://example.org/1")
+	}
+}
+
+func TestTemplates_Apply_TrimQueryHelper(t *testing.T) {
+	tmpl, err := CompileTemplates(Feed{
+		Name:        "Affiliate Feed",
+		URL:         "https://example.com/rss",
+		URLTemplate: `{{ trimQuery .Item.Link }}`,
+	})
+	if err != nil {
+		t.Fatalf("CompileTemplates: %v", err)
+	}
+
+	item := &gofeed.Item{Link: "https://example.com/1?utm_source=digest"}
+	post := tmpl.Apply(item, Post{URL: item.Link})
+	if post.URL != "https://example.com/1" {
+		t.Errorf("URL = %q, want query stripped", post.URL)
+	}
+}
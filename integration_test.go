@@ -4,11 +4,13 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -29,6 +31,7 @@ func TestIntegration_Smoke(t *testing.T) {
 		Port:            0,
 		MaxPostsPerFeed: 50,
 		FetchTimeoutSec: 10,
+		DataDir:         dataDir,
 		DataFile:        filepath.Join(dataDir, "feeds.yaml"),
 		DefaultRange:    "last_month",
 	}
@@ -43,8 +46,25 @@ func TestIntegration_Smoke(t *testing.T) {
 	}
 	defer loggers.Close()
 
+	states, err := feed.NewStateStore(filepath.Join(dataDir, "state.yaml"))
+	if err != nil {
+		t.Fatalf("NewStateStore: %v", err)
+	}
+	scheduler := feed.NewScheduler(feed.SchedulerConfig{
+		FeedsPath:          cfg.DataFile,
+		DefaultIntervalSec: cfg.RefreshIntervalSec,
+		MaxPostsPerFeed:    cfg.MaxPostsPerFeed,
+		FetchTimeout:       time.Duration(cfg.FetchTimeoutSec) * time.Second,
+	}, feed.NewStore(), states, loggers)
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	scheduler.Start(schedulerCtx)
+	defer func() {
+		stopScheduler()
+		scheduler.Stop()
+	}()
+
 	mux := http.NewServeMux()
-	api.RegisterRoutes(mux, cfg, loggers)
+	api.RegisterRoutes(mux, cfg, loggers, scheduler)
 	handler := api.CORS(mux)
 
 	ln, err := net.Listen("tcp", "localhost:0")
@@ -113,6 +133,176 @@ func TestIntegration_Smoke(t *testing.T) {
 		}
 	})
 
+	// PATCH /rss/{url} — rename the feed added above
+	t.Run("PATCH_rename_feed", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"name": "Lobsters (renamed)"})
+		req, _ := http.NewRequest(http.MethodPatch, base+"/rss/"+url.PathEscape("https://lobste.rs/rss"), bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("PATCH: %v", err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != 200 {
+			resBody, _ := io.ReadAll(res.Body)
+			t.Fatalf("status=%d body=%s", res.StatusCode, resBody)
+		}
+	})
+
+	// DELETE /rss/{url} — remove the feed added above
+	t.Run("DELETE_feed", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, base+"/rss/"+url.PathEscape("https://lobste.rs/rss"), nil)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("DELETE: %v", err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != 200 {
+			resBody, _ := io.ReadAll(res.Body)
+			t.Fatalf("status=%d body=%s", res.StatusCode, resBody)
+		}
+	})
+
+	// DELETE /rss/{url} again → 404
+	t.Run("DELETE_feed_not_found", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, base+"/rss/"+url.PathEscape("https://lobste.rs/rss"), nil)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("DELETE: %v", err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != 404 {
+			t.Errorf("status=%d, want 404", res.StatusCode)
+		}
+	})
+
+	// GET /rss?format=atom
+	t.Run("GET_atom_format", func(t *testing.T) {
+		resp, err := client.Get(base + "/rss?range=last_month&format=atom")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != "application/atom+xml; charset=utf-8" {
+			t.Errorf("Content-Type = %q, want atom", ct)
+		}
+	})
+
+	// GET /rss?format=jsonfeed
+	t.Run("GET_jsonfeed_format", func(t *testing.T) {
+		resp, err := client.Get(base + "/rss?range=last_month&format=jsonfeed")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != "application/feed+json; charset=utf-8" {
+			t.Errorf("Content-Type = %q, want jsonfeed", ct)
+		}
+		if resp.Header.Get("ETag") == "" {
+			t.Error("expected an ETag header on syndication responses")
+		}
+	})
+
+	// GET /rss?format=atom with a matching If-None-Match should 304
+	t.Run("GET_atom_conditional_not_modified", func(t *testing.T) {
+		first, err := client.Get(base + "/rss?range=last_month&format=atom")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		etag := first.Header.Get("ETag")
+		first.Body.Close()
+
+		req, _ := http.NewRequest("GET", base+"/rss?range=last_month&format=atom", nil)
+		req.Header.Set("If-None-Match", etag)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 304 {
+			t.Errorf("status=%d, want 304", resp.StatusCode)
+		}
+	})
+
+	// GET /rss?limit=1 — paginated envelope shape
+	t.Run("GET_paginated_envelope", func(t *testing.T) {
+		resp, err := client.Get(base + "/rss?range=last_month&limit=1")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+		}
+		var page struct {
+			Posts      []feed.Post `json:"posts"`
+			NextCursor string      `json:"next_cursor"`
+			HasMore    bool        `json:"has_more"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(page.Posts) > 1 {
+			t.Errorf("expected at most 1 post with limit=1, got %d", len(page.Posts))
+		}
+	})
+
+	// GET /rss?format=legacy — bare array, no pagination envelope
+	t.Run("GET_legacy_format", func(t *testing.T) {
+		resp, err := client.Get(base + "/rss?range=last_month&format=legacy")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+		}
+		var posts []feed.Post
+		if err := json.NewDecoder(resp.Body).Decode(&posts); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	})
+
+	// GET /feed.xsl
+	t.Run("GET_feed_xsl", func(t *testing.T) {
+		resp, err := client.Get(base + "/feed.xsl")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			t.Fatalf("status=%d, want 200", resp.StatusCode)
+		}
+	})
+
+	// GET /feeds/status
+	t.Run("GET_feeds_status", func(t *testing.T) {
+		resp, err := client.Get(base + "/feeds/status")
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("status=%d body=%s", resp.StatusCode, body)
+		}
+		var statuses []map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		t.Logf("got %d feed statuses", len(statuses))
+	})
+
 	// GET /rss?range=invalid → 400
 	t.Run("GET_invalid_range", func(t *testing.T) {
 		resp, err := client.Get(base + "/rss?range=invalid")
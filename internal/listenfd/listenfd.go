@@ -0,0 +1,67 @@
+// Package listenfd builds a net.Listener from a systemd socket-activation
+// file descriptor when one was handed to the process, so lexora-feed can be
+// deployed behind a systemd .socket unit (or launchd equivalent) for
+// zero-downtime restarts, falling back to a plain TCP listener otherwise.
+package listenfd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// listenFDStart is the first inherited file descriptor number under the
+// systemd socket-activation protocol; fds 0-2 are stdin/stdout/stderr.
+const listenFDStart = 3
+
+// Listen returns a listener inherited via LISTEN_PID/LISTEN_FDS if the
+// environment indicates this process was socket-activated, otherwise it
+// listens on addr itself.
+func Listen(addr string) (net.Listener, error) {
+	ln, activated, err := fromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if activated {
+		return ln, nil
+	}
+	return net.Listen("tcp", addr)
+}
+
+// fromEnv inspects the systemd socket-activation protocol: LISTEN_PID must
+// equal the current process id, and LISTEN_FDS must be a positive integer.
+// Only the first inherited fd is used, since lexora-feed only ever listens
+// on one socket. activated is false (with a nil error) when the environment
+// simply doesn't describe socket activation; err is only set when the
+// environment claims activation but is malformed or unusable.
+func fromEnv() (ln net.Listener, activated bool, err error) {
+	pidStr := os.Getenv("LISTEN_PID")
+	fdsStr := os.Getenv("LISTEN_FDS")
+	if pidStr == "" || fdsStr == "" {
+		return nil, false, nil
+	}
+
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid LISTEN_PID %q: %w", pidStr, err)
+	}
+	if pid != os.Getpid() {
+		return nil, false, nil
+	}
+
+	nfds, err := strconv.Atoi(fdsStr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid LISTEN_FDS %q: %w", fdsStr, err)
+	}
+	if nfds < 1 {
+		return nil, false, fmt.Errorf("LISTEN_FDS=%d, expected at least 1", nfds)
+	}
+
+	f := os.NewFile(uintptr(listenFDStart), "LISTEN_FD_3")
+	ln, err = net.FileListener(f)
+	if err != nil {
+		return nil, false, fmt.Errorf("build listener from inherited fd: %w", err)
+	}
+	return ln, true, nil
+}
@@ -0,0 +1,121 @@
+//go:build linux
+
+package listenfd
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestListen_FallsBackToPlainListenWithoutEnv(t *testing.T) {
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+
+	ln, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+	if ln.Addr() == nil {
+		t.Error("expected a listener with a bound address")
+	}
+}
+
+// TestListen_UsesInheritedFD dups a real listener's fd onto fd 3 to exercise
+// the socket-activation path. fd 3 is also where Go's own test machinery
+// keeps a descriptor open in the outer test binary, so this manipulation
+// runs in a re-exec'd subprocess (following the pattern os/exec's own tests
+// use for fd-sensitive cases) rather than in-process, to avoid corrupting
+// the outer process's fd table.
+func TestListen_UsesInheritedFD(t *testing.T) {
+	if os.Getenv("GO_WANT_LISTENFD_HELPER") == "1" {
+		runListenInheritedFDHelper(t)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestListen_UsesInheritedFD$", "-test.v")
+	cmd.Env = append(os.Environ(), "GO_WANT_LISTENFD_HELPER=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("helper subprocess failed: %v\n%s", err, out)
+	}
+}
+
+// runListenInheritedFDHelper is the body of TestListen_UsesInheritedFD, run
+// only inside the re-exec'd subprocess where dup'ing onto fd 3 is safe.
+func runListenInheritedFDHelper(t *testing.T) {
+	real, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen: %v", err)
+	}
+	defer real.Close()
+
+	tcpLn, ok := real.(*net.TCPListener)
+	if !ok {
+		t.Fatal("expected *net.TCPListener")
+	}
+	f, err := tcpLn.File()
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	defer f.Close()
+
+	// Duplicate the real listener's fd onto fd 3, where fromEnv expects to
+	// find an inherited socket-activation listener.
+	if err := syscall.Dup2(int(f.Fd()), listenFDStart); err != nil {
+		t.Skipf("could not dup fd onto %d: %v", listenFDStart, err)
+	}
+	defer syscall.Close(listenFDStart)
+
+	os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	os.Setenv("LISTEN_FDS", "1")
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+
+	ln, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	if ln.Addr().String() != real.Addr().String() {
+		t.Errorf("expected inherited listener to bind the same address, got %s vs %s", ln.Addr(), real.Addr())
+	}
+}
+
+func TestFromEnv_InvalidListenFDs(t *testing.T) {
+	os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	os.Setenv("LISTEN_FDS", "not-a-number")
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+
+	if _, _, err := fromEnv(); err == nil {
+		t.Error("expected error for non-numeric LISTEN_FDS")
+	}
+}
+
+func TestFromEnv_PIDMismatchFallsBackSilently(t *testing.T) {
+	os.Setenv("LISTEN_PID", "1")
+	os.Setenv("LISTEN_FDS", "1")
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+
+	ln, activated, err := fromEnv()
+	if err != nil || activated || ln != nil {
+		t.Errorf("expected silent fallback on PID mismatch, got ln=%v activated=%v err=%v", ln, activated, err)
+	}
+}
+
+func TestFromEnv_NoEnvVars(t *testing.T) {
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+
+	ln, activated, err := fromEnv()
+	if err != nil || activated || ln != nil {
+		t.Errorf("expected no activation with no env vars set, got ln=%v activated=%v err=%v", ln, activated, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"personal-kb/services/rss/api"
 	"personal-kb/services/rss/config"
 	"personal-kb/services/rss/feed"
+	"personal-kb/services/rss/internal/listenfd"
 	"personal-kb/services/rss/logging"
 )
 
@@ -46,16 +47,41 @@ func main() {
 		log.Fatalf("failed to initialize feeds file: %v", err)
 	}
 
+	// Background scheduler: keeps an in-memory post store fresh so request
+	// handlers never block on upstream fetches.
+	statePath := filepath.Join(cfg.DataDir, "state.yaml")
+	states, err := feed.NewStateStore(statePath)
+	if err != nil {
+		loggers.Error.Error("failed to load feed state", "path", statePath, "error", err.Error())
+		states, _ = feed.NewStateStore("")
+	}
+	scheduler := feed.NewScheduler(feed.SchedulerConfig{
+		FeedsPath:          feedsPath,
+		DefaultIntervalSec: cfg.RefreshIntervalSec,
+		MaxPostsPerFeed:    cfg.MaxPostsPerFeed,
+		FetchTimeout:       time.Duration(cfg.FetchTimeoutSec) * time.Second,
+		DBPath:             filepath.Join(cfg.DataDir, "posts.db"),
+		CacheDir:           cfg.CacheDir,
+		ContentCachePath:   filepath.Join(cfg.DataDir, "fulltext-cache.yaml"),
+	}, feed.NewStore(), states, loggers)
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	scheduler.Start(schedulerCtx)
+
 	// Register routes
 	mux := http.NewServeMux()
-	api.RegisterRoutes(mux, cfg, loggers)
+	api.RegisterRoutes(mux, cfg, loggers, scheduler)
 
 	// Wrap with CORS
 	handler := api.CORS(mux)
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	ln, err := listenfd.Listen(addr)
+	if err != nil {
+		log.Fatalf("failed to acquire listener: %v", err)
+	}
+
 	server := &http.Server{
-		Addr:    addr,
 		Handler: handler,
 	}
 
@@ -64,9 +90,9 @@ func main() {
 	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
-		loggers.Info.Info("RSS service started", "addr", addr, "default_range", cfg.DefaultRange)
-		fmt.Printf("RSS service listening on %s\n", addr)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		loggers.Info.Info("RSS service started", "addr", ln.Addr().String(), "default_range", cfg.DefaultRange)
+		fmt.Printf("RSS service listening on %s\n", ln.Addr())
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
 			loggers.Error.Error("server error", "error", err.Error())
 			log.Fatalf("server error: %v", err)
 		}
@@ -79,6 +105,9 @@ func main() {
 	defer cancel()
 	server.Shutdown(ctx)
 
+	stopScheduler()
+	scheduler.Stop()
+
 	loggers.Info.Info("RSS service shutdown")
 	fmt.Println("Goodbye.")
 }